@@ -0,0 +1,225 @@
+package tsafe
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// groupCountField is the field carrying the number of panics merged into a grouped entry
+const groupCountField = "count"
+
+// asyncConfig holds the settings used by NewAsyncLogger
+type asyncConfig struct {
+	groupField  string
+	groupWindow time.Duration
+}
+
+// AsyncOption configures an AsyncLogger
+type AsyncOption func(*asyncConfig)
+
+// WithGrouping batches panics whose fields share the same value for field into a single entry
+// The first panic of a group opens a window; every panic with the same value arriving within
+// it is merged, and when the window ends one entry is logged with the first panic's error,
+// its stack as the representative stack, and a "count" field holding the number of panics
+// Panics without the field are logged individually. A non-positive window disables grouping
+func WithGrouping(field string, window time.Duration) AsyncOption {
+	return func(c *asyncConfig) {
+		c.groupField = field
+		c.groupWindow = window
+	}
+}
+
+// asyncEntry is a queued log call, or a flush request when flushed is set
+type asyncEntry struct {
+	err, stack any
+	fields     map[string]any
+	flushed    chan struct{}
+}
+
+// logGroup accumulates grouped panics until its window ends
+type logGroup struct {
+	first    asyncEntry
+	count    int
+	deadline time.Time
+}
+
+// AsyncLogger is a Logger that hands panics to a background worker so the recovering
+// goroutine never waits on slow log output
+// It implements Logger, FieldLogger and Flusher. Entries are dropped when the buffer is full
+type AsyncLogger struct {
+	inner   Logger
+	cfg     asyncConfig
+	entries chan asyncEntry
+	done    chan struct{}
+	mu      sync.RWMutex // guards closed against concurrent sends
+	closed  bool
+	dropped uint64
+}
+
+// NewAsyncLogger creates an AsyncLogger forwarding to inner through a queue of buffer entries
+// A non-positive buffer is treated as 1. Call Close to stop the worker once the logger is unused
+func NewAsyncLogger(inner Logger, buffer int, opts ...AsyncOption) *AsyncLogger {
+	if buffer <= 0 {
+		buffer = 1
+	}
+	l := &AsyncLogger{
+		inner:   inner,
+		entries: make(chan asyncEntry, buffer),
+		done:    make(chan struct{}),
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&l.cfg)
+		}
+	}
+	// The worker is logging infrastructure rather than user work, so it is not tracked by Stats
+	go l.run()
+	return l
+}
+
+// Print implements the Logger interface for AsyncLogger
+func (l *AsyncLogger) Print(err, stack any) {
+	l.enqueue(asyncEntry{err: err, stack: stack})
+}
+
+// PrintFields implements the FieldLogger interface for AsyncLogger
+func (l *AsyncLogger) PrintFields(err, stack any, fields map[string]any) {
+	l.enqueue(asyncEntry{err: err, stack: stack, fields: fields})
+}
+
+// enqueue hands an entry to the worker without blocking, dropping it if the buffer is full
+func (l *AsyncLogger) enqueue(e asyncEntry) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if l.closed {
+		atomic.AddUint64(&l.dropped, 1)
+		return
+	}
+	select {
+	case l.entries <- e:
+	default:
+		atomic.AddUint64(&l.dropped, 1)
+	}
+}
+
+// Dropped returns the number of entries discarded because the buffer was full or the logger closed
+func (l *AsyncLogger) Dropped() uint64 {
+	return atomic.LoadUint64(&l.dropped)
+}
+
+// Flush blocks until every entry queued before the call, including open groups, reached the inner logger
+// It implements the Flusher interface and always returns nil
+func (l *AsyncLogger) Flush() error {
+	l.mu.RLock()
+	if l.closed {
+		l.mu.RUnlock()
+		return nil
+	}
+	flushed := make(chan struct{})
+	l.entries <- asyncEntry{flushed: flushed}
+	l.mu.RUnlock()
+	<-flushed
+	return nil
+}
+
+// Close stops accepting entries, delivers the queued ones and open groups, and waits for the worker to exit
+// Further calls are no-ops
+func (l *AsyncLogger) Close() {
+	l.mu.Lock()
+	if !l.closed {
+		l.closed = true
+		close(l.entries)
+	}
+	l.mu.Unlock()
+	<-l.done
+}
+
+// run is the worker loop delivering entries to the inner logger
+func (l *AsyncLogger) run() {
+	defer close(l.done)
+
+	groups := make(map[string]*logGroup)
+	timer := time.NewTimer(time.Hour)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		select {
+		case e, ok := <-l.entries:
+			if !ok {
+				l.flushGroups(groups, time.Time{})
+				return
+			}
+			switch {
+			case e.flushed != nil:
+				l.flushGroups(groups, time.Time{})
+				close(e.flushed)
+			case !l.group(groups, e):
+				l.deliver(e)
+			}
+		case now := <-timer.C:
+			l.flushGroups(groups, now)
+		}
+		if next, ok := nextDeadline(groups); ok {
+			timer.Reset(time.Until(next))
+		}
+	}
+}
+
+// group adds e to its group and reports whether it was grouped
+func (l *AsyncLogger) group(groups map[string]*logGroup, e asyncEntry) bool {
+	if l.cfg.groupWindow <= 0 {
+		return false
+	}
+	value, ok := e.fields[l.cfg.groupField]
+	if !ok {
+		return false
+	}
+	key := fmt.Sprint(value)
+	if g, ok := groups[key]; ok {
+		g.count++
+		return true
+	}
+	groups[key] = &logGroup{first: e, count: 1, deadline: time.Now().Add(l.cfg.groupWindow)}
+	return true
+}
+
+// flushGroups delivers the groups whose window ended by now, or all of them if now is zero
+func (l *AsyncLogger) flushGroups(groups map[string]*logGroup, now time.Time) {
+	for key, g := range groups {
+		if !now.IsZero() && now.Before(g.deadline) {
+			continue
+		}
+		delete(groups, key)
+		e := g.first
+		e.fields = mergeFields(e.fields, map[string]any{groupCountField: g.count})
+		l.deliver(e)
+	}
+}
+
+// nextDeadline returns the earliest window end among the open groups
+func nextDeadline(groups map[string]*logGroup) (time.Time, bool) {
+	var next time.Time
+	for _, g := range groups {
+		if next.IsZero() || g.deadline.Before(next) {
+			next = g.deadline
+		}
+	}
+	return next, !next.IsZero()
+}
+
+// deliver forwards an entry to the inner logger, recovering any panic it raises
+func (l *AsyncLogger) deliver(e asyncEntry) {
+	if l.inner == nil {
+		return
+	}
+	callSafely(func() {
+		if fl, ok := l.inner.(FieldLogger); ok && len(e.fields) > 0 {
+			fl.PrintFields(e.err, e.stack, e.fields)
+			return
+		}
+		l.inner.Print(e.err, e.stack)
+	})
+}
@@ -0,0 +1,105 @@
+package tsafe
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAsyncLogger(t *testing.T) {
+	t.Run("should forward entries to the inner logger", func(t *testing.T) {
+		inner := &mockFieldLogger{}
+		l := NewAsyncLogger(inner, 10)
+		defer l.Close()
+
+		l.Print("plain", "stack")
+		l.PrintFields("fielded", "stack", map[string]any{"task": "sync"})
+		assert.NoError(t, l.Flush())
+
+		assert.Equal(t, 1, inner.getCallCount())
+		assert.Equal(t, "plain", inner.getLastError())
+		calls, fields := inner.getFieldCalls()
+		assert.Equal(t, 1, calls)
+		assert.Equal(t, map[string]any{"task": "sync"}, fields)
+	})
+
+	t.Run("should group entries sharing a correlation field", func(t *testing.T) {
+		inner := &mockFieldLogger{}
+		l := NewAsyncLogger(inner, 10, WithGrouping("request_id", time.Hour))
+		defer l.Close()
+
+		for i := 0; i < 3; i++ {
+			l.PrintFields("cascade", "stack", map[string]any{"request_id": "r1"})
+		}
+		l.PrintFields("other", "stack", map[string]any{"task": "sync"})
+		assert.NoError(t, l.Flush())
+
+		calls, fields := inner.getFieldCalls()
+		assert.Equal(t, 2, calls)
+		assert.Equal(t, map[string]any{"request_id": "r1", "count": 3}, fields)
+	})
+
+	t.Run("should emit a group when its window ends", func(t *testing.T) {
+		inner := &mockFieldLogger{}
+		l := NewAsyncLogger(inner, 10, WithGrouping("request_id", 20*time.Millisecond))
+		defer l.Close()
+
+		l.PrintFields("a", "stack", map[string]any{"request_id": "r1"})
+		l.PrintFields("b", "stack", map[string]any{"request_id": "r2"})
+		l.PrintFields("c", "stack", map[string]any{"request_id": "r1"})
+
+		assert.Eventually(t, func() bool {
+			calls, _ := inner.getFieldCalls()
+			return calls == 2
+		}, time.Second, time.Millisecond)
+	})
+
+	t.Run("should drop entries when the buffer is full", func(t *testing.T) {
+		started := make(chan struct{})
+		release := make(chan struct{})
+		inner := &blockingLogger{started: started, release: release}
+		l := NewAsyncLogger(inner, 1)
+
+		l.Print("first", "stack")
+		<-started
+		l.Print("queued", "stack")
+		l.Print("dropped", "stack")
+		assert.Equal(t, uint64(1), l.Dropped())
+
+		close(release)
+		l.Close()
+		l.Print("after close", "stack")
+		assert.Equal(t, uint64(2), l.Dropped())
+	})
+
+	t.Run("should survive a panicking inner logger", func(t *testing.T) {
+		l := NewAsyncLogger(panickingLogger{}, 10)
+		defer l.Close()
+
+		l.Print("boom", "stack")
+		assert.NoError(t, l.Flush())
+	})
+}
+
+// blockingLogger blocks its first Print until release is closed
+type blockingLogger struct {
+	started chan struct{}
+	release chan struct{}
+	calls   int
+}
+
+func (b *blockingLogger) Print(err, stack any) {
+	b.calls++
+	if b.calls == 1 {
+		close(b.started)
+		<-b.release
+	}
+}
+
+// panickingLogger panics on every call
+type panickingLogger struct{}
+
+func (panickingLogger) Print(err, stack any) {
+	panic("logger panic")
+}
@@ -0,0 +1,75 @@
+package tsafe
+
+import (
+	"sync"
+	"time"
+)
+
+// BatchSpawner runs tiny tasks in batches on a single goroutine per batch, amortizing the
+// cost of goroutine creation over many fine-grained tasks
+// A batch starts once it holds size tasks, or interval after its first task was submitted,
+// whichever comes first, which bounds the latency of a task. Tasks of a batch run one after
+// another, each with its own panic recovery, so a panicking task is logged with the
+// configured logger and the rest of the batch still runs
+type BatchSpawner struct {
+	size     int
+	interval time.Duration
+	mu       sync.Mutex
+	batch    []func()
+	timer    *time.Timer // pending interval flush, nil while the batch is empty
+}
+
+// NewBatchSpawner creates a spawner running batches of up to size tasks
+// A non-positive size is treated as 1; a non-positive interval starts every batch
+// only when it is full or Flush is called
+func NewBatchSpawner(size int, interval time.Duration) *BatchSpawner {
+	if size <= 0 {
+		size = 1
+	}
+	return &BatchSpawner{size: size, interval: interval}
+}
+
+// Submit adds fn to the current batch, starting the batch if it is full
+func (b *BatchSpawner) Submit(fn func()) {
+	if fn == nil {
+		return
+	}
+	b.mu.Lock()
+	b.batch = append(b.batch, fn)
+	if len(b.batch) >= b.size {
+		b.dispatchLocked()
+	} else if b.timer == nil && b.interval > 0 {
+		b.timer = time.AfterFunc(b.interval, b.Flush)
+	}
+	b.mu.Unlock()
+}
+
+// Flush starts the current batch now, even if it is not full
+func (b *BatchSpawner) Flush() {
+	b.mu.Lock()
+	b.dispatchLocked()
+	b.mu.Unlock()
+}
+
+// dispatchLocked starts the current batch on a new goroutine; b.mu must be held
+func (b *BatchSpawner) dispatchLocked() {
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	if len(b.batch) == 0 {
+		return
+	}
+	batch := b.batch
+	b.batch = make([]func(), 0, b.size)
+	spawn(func() { runBatch(batch) })
+}
+
+// runBatch runs the tasks of a batch, recovering each one individually
+func runBatch(batch []func()) {
+	for _, fn := range batch {
+		if pe := runRecovered(fn); pe != nil {
+			reportPanic(pe.Value, pe.Stack, nil)
+		}
+	}
+}
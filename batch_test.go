@@ -0,0 +1,67 @@
+package tsafe
+
+import (
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchSpawner(t *testing.T) {
+	t.Run("should run full batches on a single goroutine", func(t *testing.T) {
+		b := NewBatchSpawner(4, time.Hour)
+		var mu sync.Mutex
+		ids := make(map[uint64]int)
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			b.Submit(func() {
+				defer wg.Done()
+				mu.Lock()
+				ids[goroutineID(debug.Stack())]++
+				mu.Unlock()
+			})
+		}
+		wg.Wait()
+
+		assert.Len(t, ids, 2)
+		for _, n := range ids {
+			assert.Equal(t, 4, n)
+		}
+	})
+
+	t.Run("should start a partial batch after the interval", func(t *testing.T) {
+		b := NewBatchSpawner(100, 10*time.Millisecond)
+		var count int32
+		for i := 0; i < 3; i++ {
+			b.Submit(func() { atomic.AddInt32(&count, 1) })
+		}
+		assert.Eventually(t, func() bool { return atomic.LoadInt32(&count) == 3 }, time.Second, time.Millisecond)
+	})
+
+	t.Run("should start a partial batch on Flush", func(t *testing.T) {
+		b := NewBatchSpawner(100, 0)
+		done := make(chan struct{})
+		b.Submit(func() { close(done) })
+		b.Flush()
+		<-done
+	})
+
+	t.Run("should run the rest of a batch after a panic", func(t *testing.T) {
+		mock := &mockLogger{}
+		SetLogger(mock)
+		defer SetLogger(&defaultLoggerImpl{})
+
+		b := NewBatchSpawner(3, time.Hour)
+		var count int32
+		b.Submit(func() { atomic.AddInt32(&count, 1) })
+		b.Submit(func() { panic("batch panic") })
+		b.Submit(func() { atomic.AddInt32(&count, 1) })
+
+		assert.Eventually(t, func() bool { return atomic.LoadInt32(&count) == 2 }, time.Second, time.Millisecond)
+		assert.Equal(t, 1, mock.getCallCount())
+	})
+}
@@ -0,0 +1,125 @@
+package tsafe
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrBreakerOpen is returned by Pool.Submit while the pool's panic breaker rejects work
+var ErrBreakerOpen = errors.New("tsafe: pool breaker is open")
+
+// BreakerState is the state of a pool's panic breaker
+type BreakerState int
+
+const (
+	// BreakerClosed accepts tasks and tracks their panic rate
+	BreakerClosed BreakerState = iota
+	// BreakerOpen rejects new tasks until the cooldown has passed
+	BreakerOpen
+	// BreakerHalfOpen accepts tasks again; the next completed task closes or reopens the breaker
+	BreakerHalfOpen
+)
+
+// String returns the lower-case name of the state
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// breaker trips when the panic rate of completed tasks exceeds a threshold
+type breaker struct {
+	threshold float64
+	minTasks  int
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	state    BreakerState
+	openedAt time.Time
+	tasks    int // tasks completed since the breaker last closed
+	panics   int // of which panicked
+}
+
+// allow reports ErrBreakerOpen while the breaker is open
+func (b *breaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.currentState() == BreakerOpen {
+		return ErrBreakerOpen
+	}
+	return nil
+}
+
+// record accounts for a completed task
+// Tasks finishing while the breaker is open were started before it tripped and are ignored
+func (b *breaker) record(panicked bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.currentState() {
+	case BreakerHalfOpen:
+		if panicked {
+			b.trip()
+			return
+		}
+		b.state = BreakerClosed
+		b.tasks, b.panics = 0, 0
+	case BreakerClosed:
+		b.tasks++
+		if panicked {
+			b.panics++
+		}
+		if b.tasks >= b.minTasks && float64(b.panics)/float64(b.tasks) > b.threshold {
+			b.trip()
+		}
+	}
+}
+
+// trip opens the breaker; the caller holds mu
+func (b *breaker) trip() {
+	b.state = BreakerOpen
+	b.openedAt = time.Now()
+	b.tasks, b.panics = 0, 0
+}
+
+// currentState moves an open breaker to half-open once the cooldown has passed; the caller holds mu
+func (b *breaker) currentState() BreakerState {
+	if b.state == BreakerOpen && time.Since(b.openedAt) >= b.cooldown {
+		b.state = BreakerHalfOpen
+	}
+	return b.state
+}
+
+// PoolOption configures a Pool
+type PoolOption func(*Pool)
+
+// WithBreaker makes the pool reject submissions with ErrBreakerOpen once the fraction of
+// completed tasks that panicked exceeds threshold, protecting it from a poison workload
+// The rate is cumulative since the breaker last closed and only evaluated after minTasks
+// tasks completed. After cooldown the breaker is half-open: new tasks are accepted, and the
+// next task to complete closes the breaker if it succeeds or reopens it if it panics
+// Tasks already queued or running when the breaker opens still run, are recovered and release their slot
+func WithBreaker(threshold float64, minTasks int, cooldown time.Duration) PoolOption {
+	return func(p *Pool) {
+		if minTasks < 1 {
+			minTasks = 1
+		}
+		p.breaker = &breaker{threshold: threshold, minTasks: minTasks, cooldown: cooldown}
+	}
+}
+
+// State returns the state of the pool's breaker, or BreakerClosed if it has none
+func (p *Pool) State() BreakerState {
+	if p.breaker == nil {
+		return BreakerClosed
+	}
+	p.breaker.mu.Lock()
+	defer p.breaker.mu.Unlock()
+	return p.breaker.currentState()
+}
@@ -0,0 +1,82 @@
+package tsafe
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoolBreaker(t *testing.T) {
+	SetLogger(&mockLogger{})
+	defer SetLogger(&defaultLoggerImpl{})
+
+	t.Run("should reject submissions once the panic rate is exceeded", func(t *testing.T) {
+		pool := NewPool(1, WithBreaker(0.5, 2, time.Hour))
+		assert.Equal(t, BreakerClosed, pool.State())
+
+		assert.NoError(t, pool.Submit(func() {}))
+		assert.NoError(t, pool.Submit(func() { panic("poison") }))
+		pool.WaitIdle()
+		assert.Equal(t, BreakerClosed, pool.State(), "1 of 2 is not above the threshold")
+
+		assert.NoError(t, pool.Submit(func() { panic("poison") }))
+		pool.WaitIdle()
+		assert.Equal(t, BreakerOpen, pool.State())
+
+		var ran int32
+		assert.ErrorIs(t, pool.Submit(func() { atomic.AddInt32(&ran, 1) }), ErrBreakerOpen)
+		assert.ErrorIs(t, pool.SubmitRecover(func() {}, nil), ErrBreakerOpen)
+		pool.WaitIdle()
+		assert.Equal(t, int32(0), atomic.LoadInt32(&ran))
+	})
+
+	t.Run("should close after the cooldown when a task succeeds", func(t *testing.T) {
+		pool := NewPool(1, WithBreaker(0, 1, 20*time.Millisecond))
+		assert.NoError(t, pool.Submit(func() { panic("poison") }))
+		pool.WaitIdle()
+		assert.Equal(t, BreakerOpen, pool.State())
+
+		time.Sleep(30 * time.Millisecond)
+		assert.Equal(t, BreakerHalfOpen, pool.State())
+		assert.NoError(t, pool.Submit(func() {}))
+		pool.WaitIdle()
+		assert.Equal(t, BreakerClosed, pool.State())
+	})
+
+	t.Run("should reopen when the half-open trial panics", func(t *testing.T) {
+		pool := NewPool(1, WithBreaker(0, 1, 20*time.Millisecond))
+		assert.NoError(t, pool.Submit(func() { panic("poison") }))
+		pool.WaitIdle()
+
+		time.Sleep(30 * time.Millisecond)
+		assert.NoError(t, pool.Submit(func() { panic("still poison") }))
+		pool.WaitIdle()
+		assert.Equal(t, BreakerOpen, pool.State())
+	})
+
+	t.Run("should finish running and queued tasks while open", func(t *testing.T) {
+		pool := NewPool(1, WithBreaker(0, 1, time.Hour))
+		release := make(chan struct{})
+		var completed int32
+
+		assert.NoError(t, pool.Submit(func() {
+			<-release
+			panic("poison")
+		}))
+		assert.NoError(t, pool.Submit(func() { atomic.AddInt32(&completed, 1) }))
+		close(release)
+		pool.WaitIdle()
+
+		assert.Equal(t, BreakerOpen, pool.State())
+		assert.Equal(t, int32(1), atomic.LoadInt32(&completed))
+		assert.Equal(t, 0, pool.Running())
+	})
+
+	t.Run("should report closed without a breaker", func(t *testing.T) {
+		pool := NewPool(1)
+		assert.Equal(t, BreakerClosed, pool.State())
+		assert.Equal(t, "half-open", BreakerHalfOpen.String())
+	})
+}
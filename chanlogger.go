@@ -0,0 +1,112 @@
+package tsafe
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// channelLoggerConfig holds the settings used by NewChannelLogger
+type channelLoggerConfig struct {
+	block bool
+}
+
+// ChannelLoggerOption configures a logger created by NewChannelLogger
+type ChannelLoggerOption func(*channelLoggerConfig)
+
+// WithBlockingWhenFull makes the logger wait for room in the channel instead of dropping
+// events while it is full. The recovering goroutine then blocks until the consumer catches up
+func WithBlockingWhenFull() ChannelLoggerOption {
+	return func(c *channelLoggerConfig) {
+		c.block = true
+	}
+}
+
+// ChannelLogger is a Logger delivering every panic as a PanicEvent on a channel, so panics
+// can be consumed in a goroutine of the caller's choice, or asserted on in tests without
+// shared mutable mock state
+// It implements Logger, FieldLogger, LeveledLogger and LeveledFieldLogger, so events carry the
+// level of the panic; only direct calls to Print or PrintFields carry LevelError
+type ChannelLogger struct {
+	cfg     channelLoggerConfig
+	events  chan PanicEvent
+	dropped uint64
+}
+
+// NewChannelLogger creates a ChannelLogger and returns it with the channel receiving its events
+// The channel holds up to buffer events; a negative buffer is treated as 0. While it is full,
+// events are dropped unless WithBlockingWhenFull is given. The channel is never closed
+func NewChannelLogger(buffer int, opts ...ChannelLoggerOption) (*ChannelLogger, <-chan PanicEvent) {
+	if buffer < 0 {
+		buffer = 0
+	}
+	l := &ChannelLogger{events: make(chan PanicEvent, buffer)}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&l.cfg)
+		}
+	}
+	return l, l.events
+}
+
+// Print implements the Logger interface for ChannelLogger
+func (l *ChannelLogger) Print(err, stack any) {
+	l.send(err, stack, nil, LevelError)
+}
+
+// PrintFields implements the FieldLogger interface for ChannelLogger
+func (l *ChannelLogger) PrintFields(err, stack any, fields map[string]any) {
+	l.send(err, stack, fields, LevelError)
+}
+
+// PrintLevel implements the LeveledLogger interface for ChannelLogger
+func (l *ChannelLogger) PrintLevel(level Level, err, stack any) {
+	l.send(err, stack, nil, level)
+}
+
+// PrintLevelFields implements the LeveledFieldLogger interface for ChannelLogger
+func (l *ChannelLogger) PrintLevelFields(level Level, err, stack any, fields map[string]any) {
+	l.send(err, stack, fields, level)
+}
+
+// Dropped returns the number of events discarded because the channel was full
+func (l *ChannelLogger) Dropped() uint64 {
+	return atomic.LoadUint64(&l.dropped)
+}
+
+// send builds the event for a log call and delivers it to the channel
+func (l *ChannelLogger) send(err, stack any, fields map[string]any, level Level) {
+	stackBytes := toStackBytes(stack)
+	event := PanicEvent{
+		Time:        time.Now(),
+		Value:       err,
+		Stack:       stackBytes,
+		Fields:      fields,
+		GoroutineID: goroutineID(stackBytes),
+		Level:       level,
+		Causes:      causeChain(err),
+	}
+	if l.cfg.block {
+		l.events <- event
+		return
+	}
+	select {
+	case l.events <- event:
+	default:
+		atomic.AddUint64(&l.dropped, 1)
+	}
+}
+
+// toStackBytes converts a stack passed to a logger to bytes
+func toStackBytes(stack any) []byte {
+	switch s := stack.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return s
+	case string:
+		return []byte(s)
+	default:
+		return []byte(fmt.Sprint(s))
+	}
+}
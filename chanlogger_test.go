@@ -0,0 +1,82 @@
+package tsafe
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewChannelLogger(t *testing.T) {
+	t.Run("should deliver panics as events", func(t *testing.T) {
+		logger, events := NewChannelLogger(10)
+		SetLogger(logger)
+		defer SetLogger(&defaultLoggerImpl{})
+
+		GoWithFields(map[string]any{"task": "sync"}, func() { panic("channel panic") })
+
+		select {
+		case event := <-events:
+			assert.Equal(t, "channel panic", event.Value)
+			assert.Equal(t, map[string]any{"task": "sync"}, event.Fields)
+			assert.Equal(t, LevelError, event.Level)
+			assert.NotEmpty(t, event.Stack)
+			assert.NotZero(t, event.GoroutineID)
+		case <-time.After(time.Second):
+			t.Fatal("no event received")
+		}
+	})
+
+	t.Run("should carry the level from PrintLevel", func(t *testing.T) {
+		logger, events := NewChannelLogger(1)
+		logger.PrintLevel(LevelWarn, "warning", "stack")
+		event := <-events
+		assert.Equal(t, LevelWarn, event.Level)
+		assert.Equal(t, []byte("stack"), event.Stack)
+	})
+
+	t.Run("should carry the classified level together with fields", func(t *testing.T) {
+		logger, events := NewChannelLogger(10)
+		SetLogger(logger)
+		defer SetLogger(&defaultLoggerImpl{})
+		SetLevelClassifier(func(err any) Level { return LevelWarn })
+		defer SetLevelClassifier(nil)
+
+		GoWithFields(map[string]any{"task": "sync"}, func() { panic("channel panic") })
+
+		select {
+		case event := <-events:
+			assert.Equal(t, LevelWarn, event.Level)
+			assert.Equal(t, map[string]any{"task": "sync"}, event.Fields)
+		case <-time.After(time.Second):
+			t.Fatal("no event received")
+		}
+	})
+
+	t.Run("should drop events while the channel is full", func(t *testing.T) {
+		logger, events := NewChannelLogger(1)
+		logger.Print("first", nil)
+		logger.Print("second", nil)
+
+		assert.Equal(t, uint64(1), logger.Dropped())
+		assert.Equal(t, "first", (<-events).Value)
+	})
+
+	t.Run("should block while full with WithBlockingWhenFull", func(t *testing.T) {
+		logger, events := NewChannelLogger(0, WithBlockingWhenFull())
+		sent := make(chan struct{})
+		go func() {
+			logger.Print("blocked", nil)
+			close(sent)
+		}()
+
+		select {
+		case <-sent:
+			t.Fatal("send did not block")
+		case <-time.After(10 * time.Millisecond):
+		}
+		assert.Equal(t, "blocked", (<-events).Value)
+		<-sent
+		assert.Zero(t, logger.Dropped())
+	})
+}
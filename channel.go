@@ -0,0 +1,92 @@
+package tsafe
+
+import "sync"
+
+// Channel is a channel wrapper that can be closed with an error, so consumers learn why a
+// stream ended instead of just seeing it end
+// Values sent before the close are still received; afterwards Recv reports the close error.
+// Sending on a closed Channel does not panic. Use GoProducer to close it automatically with the
+// *PanicError of a panicking producer, propagating failures through pipelines
+type Channel[T any] struct {
+	data      chan T
+	done      chan struct{}
+	closeOnce sync.Once
+	err       error // set before done is closed
+}
+
+// NewChannel creates an open Channel holding up to buffer unreceived values
+// A non-positive buffer makes it unbuffered
+func NewChannel[T any](buffer int) *Channel[T] {
+	if buffer < 0 {
+		buffer = 0
+	}
+	return &Channel[T]{data: make(chan T, buffer), done: make(chan struct{})}
+}
+
+// GoProducer creates a Channel like NewChannel and starts producer in a recovered goroutine
+// to fill it. When producer returns the channel is closed; when it panics the channel is
+// closed with the *PanicError, which consumers get from Recv once they drained the values
+func GoProducer[T any](buffer int, producer func(ch *Channel[T])) *Channel[T] {
+	ch := NewChannel[T](buffer)
+	if producer == nil {
+		ch.Close()
+		return ch
+	}
+	spawn(func() {
+		if pe := runRecovered(func() { producer(ch) }); pe != nil {
+			ch.CloseWithError(pe)
+			return
+		}
+		ch.Close()
+	})
+	return ch
+}
+
+// Send sends v, blocking until a consumer or the buffer takes it, and reports whether it was sent
+// It returns false without sending once the channel is closed
+func (c *Channel[T]) Send(v T) bool {
+	select {
+	case <-c.done:
+		return false
+	default:
+	}
+	select {
+	case c.data <- v:
+		return true
+	case <-c.done:
+		return false
+	}
+}
+
+// Close closes the channel without an error; it is CloseWithError(nil)
+func (c *Channel[T]) Close() {
+	c.CloseWithError(nil)
+}
+
+// CloseWithError closes the channel so consumers receive err once they drained the sent values
+// Only the first close takes effect; later calls are no-ops
+func (c *Channel[T]) CloseWithError(err error) {
+	c.closeOnce.Do(func() {
+		c.err = err
+		close(c.done)
+	})
+}
+
+// Recv blocks until a value is available or the channel is closed and drained
+// It returns the value and true, or the zero value, false and the close error, which is nil
+// after a plain Close
+func (c *Channel[T]) Recv() (T, bool, error) {
+	select {
+	case v := <-c.data:
+		return v, true, nil
+	case <-c.done:
+	}
+	// Closed: deliver values sent before the close first
+	select {
+	case v := <-c.data:
+		return v, true, nil
+	default:
+		var zero T
+		return zero, false, c.err
+	}
+}
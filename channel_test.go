@@ -0,0 +1,94 @@
+package tsafe
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChannel(t *testing.T) {
+	t.Run("should deliver sent values before the close", func(t *testing.T) {
+		ch := NewChannel[int](3)
+		assert.True(t, ch.Send(1))
+		assert.True(t, ch.Send(2))
+		ch.Close()
+		assert.False(t, ch.Send(3))
+
+		for _, want := range []int{1, 2} {
+			v, ok, err := ch.Recv()
+			assert.True(t, ok)
+			assert.NoError(t, err)
+			assert.Equal(t, want, v)
+		}
+		v, ok, err := ch.Recv()
+		assert.False(t, ok)
+		assert.NoError(t, err)
+		assert.Zero(t, v)
+	})
+
+	t.Run("should report the close error after draining", func(t *testing.T) {
+		failure := errors.New("upstream failed")
+		ch := NewChannel[string](1)
+		ch.Send("last")
+		ch.CloseWithError(failure)
+		ch.CloseWithError(errors.New("ignored"))
+
+		v, ok, err := ch.Recv()
+		assert.Equal(t, "last", v)
+		assert.True(t, ok)
+		assert.NoError(t, err)
+
+		_, ok, err = ch.Recv()
+		assert.False(t, ok)
+		assert.Equal(t, failure, err)
+	})
+
+	t.Run("should unblock a sender when closed", func(t *testing.T) {
+		ch := NewChannel[int](0)
+		sent := make(chan bool)
+		go func() { sent <- ch.Send(1) }()
+		ch.Close()
+		assert.False(t, <-sent)
+	})
+}
+
+func TestGoProducer(t *testing.T) {
+	t.Run("should close the channel when the producer returns", func(t *testing.T) {
+		ch := GoProducer(0, func(ch *Channel[int]) {
+			for i := 0; i < 3; i++ {
+				ch.Send(i)
+			}
+		})
+
+		var got []int
+		for {
+			v, ok, err := ch.Recv()
+			if !ok {
+				assert.NoError(t, err)
+				break
+			}
+			got = append(got, v)
+		}
+		assert.Equal(t, []int{0, 1, 2}, got)
+	})
+
+	t.Run("should propagate a producer panic to consumers", func(t *testing.T) {
+		ch := GoProducer(0, func(ch *Channel[int]) {
+			ch.Send(1)
+			panic("producer panic")
+		})
+
+		v, ok, err := ch.Recv()
+		assert.Equal(t, 1, v)
+		assert.True(t, ok)
+		assert.NoError(t, err)
+
+		_, ok, err = ch.Recv()
+		assert.False(t, ok)
+		var pe *PanicError
+		if assert.True(t, errors.As(err, &pe)) {
+			assert.Equal(t, "producer panic", pe.Value)
+		}
+	})
+}
@@ -0,0 +1,6 @@
+package tsafe
+
+import "errors"
+
+// ErrChaos is the panic value injected by chaos testing, see SetChaos
+var ErrChaos = errors.New("tsafe: chaos injected panic")
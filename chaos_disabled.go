@@ -0,0 +1,12 @@
+//go:build !tsafe_chaos
+
+package tsafe
+
+// SetChaos enables random panic injection in binaries built with the tsafe_chaos build tag
+// This build does not include chaos support, so SetChaos does nothing
+func SetChaos(probability float64) {}
+
+// injectChaos returns goroutine unchanged when chaos support is not compiled in
+func injectChaos(goroutine func()) func() {
+	return goroutine
+}
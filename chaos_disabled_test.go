@@ -0,0 +1,18 @@
+//go:build !tsafe_chaos
+
+package tsafe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetChaosDisabled(t *testing.T) {
+	SetChaos(1)
+	ran := false
+	assert.NotPanics(t, func() {
+		injectChaos(func() { ran = true })()
+	})
+	assert.True(t, ran)
+}
@@ -0,0 +1,35 @@
+//go:build tsafe_chaos
+
+package tsafe
+
+import (
+	"math"
+	"math/rand"
+	"sync/atomic"
+)
+
+// chaosProbability holds the float64 bits of the injection probability
+var chaosProbability uint64
+
+// SetChaos makes goroutines started through GoWithRecover (and therefore Go and the other
+// package-level spawners) panic with ErrChaos at start with the given probability
+// It exercises recovery paths, loggers and supervisors. The probability is clamped to [0, 1]
+// and 0 disables injection
+// Chaos injection only exists in binaries built with the tsafe_chaos build tag; in every
+// other build SetChaos is a no-op, so it cannot accidentally ship to production
+// This function is thread-safe
+func SetChaos(probability float64) {
+	probability = math.Max(0, math.Min(1, probability))
+	atomic.StoreUint64(&chaosProbability, math.Float64bits(probability))
+}
+
+// injectChaos wraps goroutine so that it may panic with ErrChaos before running
+func injectChaos(goroutine func()) func() {
+	probability := math.Float64frombits(atomic.LoadUint64(&chaosProbability))
+	if probability == 0 || rand.Float64() >= probability {
+		return goroutine
+	}
+	return func() {
+		panic(ErrChaos)
+	}
+}
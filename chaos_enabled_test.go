@@ -0,0 +1,60 @@
+//go:build tsafe_chaos
+
+package tsafe
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetChaos(t *testing.T) {
+	t.Run("should inject panics with probability 1", func(t *testing.T) {
+		SetChaos(1)
+		defer SetChaos(0)
+
+		recovered := make(chan any, 1)
+		ran := make(chan struct{}, 1)
+		GoWithRecover(func() {
+			ran <- struct{}{}
+		}, func(err any) {
+			recovered <- err
+		})
+
+		select {
+		case err := <-recovered:
+			assert.Equal(t, ErrChaos, err)
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("chaos panic was not injected")
+		}
+		assert.Len(t, ran, 0)
+	})
+
+	t.Run("should release the tag slot of a replaced goroutine", func(t *testing.T) {
+		SetLogger(&mockLogger{})
+		defer SetLogger(&defaultLoggerImpl{})
+		SetTagLimit("chaos", 1)
+		defer SetTagLimit("chaos", 0)
+
+		SetChaos(1)
+		GoTagged("chaos", func(ctx context.Context) {})
+		SetChaos(0)
+
+		done := make(chan struct{})
+		go GoTagged("chaos", func(ctx context.Context) { close(done) })
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("slot leaked by the replaced goroutine")
+		}
+	})
+
+	t.Run("should not inject panics when disabled", func(t *testing.T) {
+		SetChaos(0)
+		ran := false
+		injectChaos(func() { ran = true })()
+		assert.True(t, ran)
+	})
+}
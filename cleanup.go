@@ -0,0 +1,35 @@
+package tsafe
+
+// GoWithCleanup starts a goroutine that runs the cleanup functions after goroutine returns or panics
+// Cleanups run in reverse order, like deferred calls, and each one is recovered individually
+// so a panicking cleanup cannot mask the original panic or skip the remaining cleanups
+// A single panic is logged as-is; multiple panics are logged together as a *MultiPanicError
+func GoWithCleanup(goroutine func(), cleanup ...func()) {
+	if goroutine == nil {
+		return // Avoid creating goroutine for nil function
+	}
+
+	spawn(func() {
+		var panics []*PanicError
+		if pe := runRecovered(goroutine); pe != nil {
+			panics = append(panics, pe)
+		}
+		for i := len(cleanup) - 1; i >= 0; i-- {
+			if cleanup[i] == nil {
+				continue
+			}
+			if pe := runRecovered(cleanup[i]); pe != nil {
+				panics = append(panics, pe)
+			}
+		}
+
+		switch len(panics) {
+		case 0:
+		case 1:
+			reportPanic(panics[0].Value, panics[0].Stack, nil)
+		default:
+			multi := &MultiPanicError{Panics: panics}
+			reportPanic(multi, multi.Stack(), nil)
+		}
+	})
+}
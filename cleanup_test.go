@@ -0,0 +1,89 @@
+package tsafe
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoWithCleanup(t *testing.T) {
+	t.Run("should run cleanups in reverse order", func(t *testing.T) {
+		var mu sync.Mutex
+		var order []int
+		record := func(i int) func() {
+			return func() {
+				mu.Lock()
+				defer mu.Unlock()
+				order = append(order, i)
+			}
+		}
+
+		done := make(chan struct{})
+		GoWithCleanup(record(0), func() { close(done) }, record(2), record(1))
+
+		select {
+		case <-done:
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("cleanups did not run")
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, []int{0, 1, 2}, order)
+	})
+
+	t.Run("should report both panics when main and cleanup panic", func(t *testing.T) {
+		mock := &mockLogger{}
+		originalLogger := getLogger()
+		SetLogger(mock)
+		defer SetLogger(originalLogger)
+
+		GoWithCleanup(func() {
+			panic("main panic")
+		}, func() {
+			panic("cleanup panic")
+		})
+
+		assert.Eventually(t, func() bool {
+			return mock.getCallCount() == 1
+		}, 100*time.Millisecond, time.Millisecond)
+
+		multi, ok := mock.getLastError().(*MultiPanicError)
+		if assert.True(t, ok) {
+			assert.Len(t, multi.Panics, 2)
+			assert.Equal(t, "main panic", multi.Panics[0].Value)
+			assert.Equal(t, "cleanup panic", multi.Panics[1].Value)
+			assert.True(t, errors.Is(multi, ErrPanic))
+			assert.Contains(t, multi.Error(), "main panic")
+			assert.Contains(t, multi.Error(), "cleanup panic")
+		}
+	})
+
+	t.Run("should report a single panic unchanged", func(t *testing.T) {
+		mock := &mockLogger{}
+		originalLogger := getLogger()
+		SetLogger(mock)
+		defer SetLogger(originalLogger)
+
+		cleaned := make(chan struct{}, 1)
+		GoWithCleanup(func() {
+			panic("only panic")
+		}, func() {
+			cleaned <- struct{}{}
+		})
+
+		assert.Eventually(t, func() bool {
+			return mock.getCallCount() == 1
+		}, 100*time.Millisecond, time.Millisecond)
+		assert.Equal(t, "only panic", mock.getLastError())
+		assert.Len(t, cleaned, 1)
+	})
+
+	t.Run("should handle nil function gracefully", func(t *testing.T) {
+		assert.NotPanics(t, func() {
+			GoWithCleanup(nil)
+		})
+	})
+}
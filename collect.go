@@ -0,0 +1,47 @@
+package tsafe
+
+import "sync"
+
+// ResultGroup runs tasks producing values in recovered goroutines and gathers their results,
+// for scatter-gather where only the successful results matter, in no particular order
+// A panicking task is logged with the configured logger and contributes nothing
+// The zero value is ready to use
+type ResultGroup[T any] struct {
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+	results []T
+}
+
+// Go starts fn in a new goroutine that belongs to the group; its result is collected
+func (g *ResultGroup[T]) Go(fn func() T) {
+	if fn == nil {
+		return // Avoid creating goroutine for nil function
+	}
+
+	g.wg.Add(1)
+	spawn(func() {
+		defer g.wg.Done()
+		var v T
+		if pe := runRecovered(func() { v = fn() }); pe != nil {
+			reportPanic(pe.Value, pe.Stack, nil)
+			return
+		}
+		g.mu.Lock()
+		g.results = append(g.results, v)
+		g.mu.Unlock()
+	})
+}
+
+// Wait blocks until all tasks have finished
+func (g *ResultGroup[T]) Wait() {
+	g.wg.Wait()
+}
+
+// Collect waits for all tasks and returns the results of those that did not panic,
+// in completion order
+func (g *ResultGroup[T]) Collect() []T {
+	g.Wait()
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return append([]T(nil), g.results...)
+}
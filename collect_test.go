@@ -0,0 +1,41 @@
+package tsafe
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResultGroup(t *testing.T) {
+	t.Run("should collect every result", func(t *testing.T) {
+		var g ResultGroup[int]
+		for i := 0; i < 10; i++ {
+			i := i
+			g.Go(func() int { return i * i })
+		}
+
+		results := g.Collect()
+		sort.Ints(results)
+		assert.Equal(t, []int{0, 1, 4, 9, 16, 25, 36, 49, 64, 81}, results)
+	})
+
+	t.Run("should skip and log panicking tasks", func(t *testing.T) {
+		mock := &mockLogger{}
+		SetLogger(mock)
+		defer SetLogger(&defaultLoggerImpl{})
+
+		var g ResultGroup[string]
+		g.Go(func() string { return "ok" })
+		g.Go(func() string { panic("scatter panic") })
+		g.Go(nil)
+
+		assert.Equal(t, []string{"ok"}, g.Collect())
+		assert.Equal(t, 1, mock.getCallCount())
+	})
+
+	t.Run("should return an empty result for an empty group", func(t *testing.T) {
+		var g ResultGroup[int]
+		assert.Empty(t, g.Collect())
+	})
+}
@@ -0,0 +1,38 @@
+package tsafe
+
+import "sync"
+
+// Consumer is the handle of a set of workers started by GoConsume
+type Consumer struct {
+	wg sync.WaitGroup
+}
+
+// Wait blocks until the channel has been drained and closed and every worker has exited
+func (c *Consumer) Wait() {
+	c.wg.Wait()
+}
+
+// GoConsume starts workers recovered goroutines that read from ch until it is closed,
+// calling fn for every item
+// A panic in fn is logged with the configured logger and the worker continues with the next item
+// A non-positive workers count is treated as 1
+func GoConsume[T any](ch <-chan T, workers int, fn func(T)) *Consumer {
+	if workers <= 0 {
+		workers = 1
+	}
+
+	c := &Consumer{}
+	for i := 0; i < workers; i++ {
+		c.wg.Add(1)
+		spawn(func() {
+			defer c.wg.Done()
+			for item := range ch {
+				item := item
+				if pe := runRecovered(func() { fn(item) }); pe != nil {
+					reportPanic(pe.Value, pe.Stack, nil)
+				}
+			}
+		})
+	}
+	return c
+}
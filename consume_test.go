@@ -0,0 +1,71 @@
+package tsafe
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoConsume(t *testing.T) {
+	t.Run("should process every item until the channel is closed", func(t *testing.T) {
+		ch := make(chan int)
+		var sum int64
+		consumer := GoConsume(ch, 4, func(i int) {
+			atomic.AddInt64(&sum, int64(i))
+		})
+
+		for i := 1; i <= 100; i++ {
+			ch <- i
+		}
+		close(ch)
+		consumer.Wait()
+		assert.Equal(t, int64(5050), atomic.LoadInt64(&sum))
+	})
+
+	t.Run("should keep consuming after a panicking item", func(t *testing.T) {
+		mock := &mockLogger{}
+		originalLogger := getLogger()
+		SetLogger(mock)
+		defer SetLogger(originalLogger)
+
+		ch := make(chan int, 3)
+		var processed int32
+		consumer := GoConsume(ch, 1, func(i int) {
+			if i == 2 {
+				panic("bad item")
+			}
+			atomic.AddInt32(&processed, 1)
+		})
+
+		ch <- 1
+		ch <- 2
+		ch <- 3
+		close(ch)
+		consumer.Wait()
+
+		assert.Equal(t, int32(2), atomic.LoadInt32(&processed))
+		assert.Equal(t, 1, mock.getCallCount())
+		assert.Equal(t, "bad item", mock.getLastError())
+	})
+
+	t.Run("should not exit before the channel is closed", func(t *testing.T) {
+		ch := make(chan int)
+		consumer := GoConsume(ch, 2, func(int) {})
+
+		done := make(chan struct{})
+		go func() {
+			consumer.Wait()
+			close(done)
+		}()
+
+		select {
+		case <-done:
+			t.Fatal("workers exited before the channel was closed")
+		case <-time.After(20 * time.Millisecond):
+		}
+		close(ch)
+		<-done
+	})
+}
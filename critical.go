@@ -0,0 +1,41 @@
+package tsafe
+
+import (
+	"os"
+	"runtime/debug"
+	"sync/atomic"
+)
+
+// Flusher is implemented by loggers that buffer output and can flush it on demand
+type Flusher interface {
+	// Flush writes any buffered log entries
+	Flush() error
+}
+
+// criticalExitCode is the process exit code used by GoCritical
+var criticalExitCode int32 = 1
+
+// exitFunc terminates the process; replaced in tests
+var exitFunc = os.Exit
+
+// SetCriticalExitCode sets the exit code used when a GoCritical goroutine panics
+// The default is 1
+// This function is thread-safe
+func SetCriticalExitCode(code int) {
+	atomic.StoreInt32(&criticalExitCode, int32(code))
+}
+
+// GoCritical starts a goroutine whose panic terminates the whole process
+// The panic is reported to the sinks and the configured logger like any other, FlushAll waits
+// up to five seconds for buffered entries to be written, and then the process exits with the
+// code configured by SetCriticalExitCode
+// Use it for goroutines the process cannot run without, under an external supervisor
+// (e.g. systemd or Kubernetes) that restarts the process
+func GoCritical(goroutine func()) {
+	GoWithRecover(goroutine, func(err any) {
+		reportPanic(err, debug.Stack(), nil)
+		flushBeforeCrash()
+		_ = os.Stderr.Sync()
+		exitFunc(int(atomic.LoadInt32(&criticalExitCode)))
+	})
+}
@@ -0,0 +1,96 @@
+package tsafe
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// flushingLogger records flushes in addition to printed errors
+type flushingLogger struct {
+	mockLogger
+	flushed chan struct{}
+}
+
+func (f *flushingLogger) Flush() error {
+	close(f.flushed)
+	return nil
+}
+
+func TestGoCritical(t *testing.T) {
+	t.Run("should log, flush and exit on panic", func(t *testing.T) {
+		logger := &flushingLogger{flushed: make(chan struct{})}
+		originalLogger := getLogger()
+		SetLogger(logger)
+		defer SetLogger(originalLogger)
+
+		exitCodes := make(chan int, 1)
+		originalExit := exitFunc
+		exitFunc = func(code int) { exitCodes <- code }
+		defer func() { exitFunc = originalExit }()
+
+		SetCriticalExitCode(3)
+		defer SetCriticalExitCode(1)
+
+		GoCritical(func() {
+			panic("critical failure")
+		})
+
+		select {
+		case code := <-exitCodes:
+			assert.Equal(t, 3, code)
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("process exit was not requested")
+		}
+		assert.Equal(t, "critical failure", logger.getLastError())
+		assert.Equal(t, 1, logger.getCallCount())
+
+		select {
+		case <-logger.flushed:
+		default:
+			t.Fatal("logger was not flushed before exit")
+		}
+	})
+
+	t.Run("should report to sinks and exit despite a panicking logger", func(t *testing.T) {
+		SetLogger(panickingLogger{})
+		defer SetLogger(&defaultLoggerImpl{})
+		sink := &recordingSink{}
+		defer RegisterSink(sink)()
+
+		exitCodes := make(chan int, 1)
+		originalExit := exitFunc
+		exitFunc = func(code int) { exitCodes <- code }
+		defer func() { exitFunc = originalExit }()
+
+		GoCritical(func() {
+			panic("critical failure")
+		})
+
+		select {
+		case code := <-exitCodes:
+			assert.Equal(t, 1, code)
+		case <-time.After(time.Second):
+			t.Fatal("process exit was not requested")
+		}
+		if events := sink.getEvents(); assert.Len(t, events, 1) {
+			assert.Equal(t, "critical failure", events[0].Value)
+		}
+	})
+
+	t.Run("should not exit on normal completion", func(t *testing.T) {
+		exitCodes := make(chan int, 1)
+		originalExit := exitFunc
+		exitFunc = func(code int) { exitCodes <- code }
+		defer func() { exitFunc = originalExit }()
+
+		done := make(chan struct{})
+		GoCritical(func() {
+			close(done)
+		})
+		<-done
+		time.Sleep(10 * time.Millisecond)
+		assert.Len(t, exitCodes, 0)
+	})
+}
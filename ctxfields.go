@@ -0,0 +1,27 @@
+package tsafe
+
+import "context"
+
+// fieldsKey is the context key holding the fields set by WithFields
+type fieldsKey struct{}
+
+// WithFields returns a copy of ctx carrying fields for the panic reports of goroutines
+// started with it through GoCtx
+// Fields already set on ctx are inherited, with fields winning on key conflicts, so nested
+// calls build up hierarchical logging context. The map is copied
+func WithFields(ctx context.Context, fields map[string]any) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, fieldsKey{}, mergeFields(contextFields(ctx), fields))
+}
+
+// contextFields returns the fields set on ctx by WithFields, or nil
+// The returned map must not be modified
+func contextFields(ctx context.Context) map[string]any {
+	if ctx == nil {
+		return nil
+	}
+	fields, _ := ctx.Value(fieldsKey{}).(map[string]any)
+	return fields
+}
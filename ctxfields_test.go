@@ -0,0 +1,44 @@
+package tsafe
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithFields(t *testing.T) {
+	t.Run("should inherit parent fields with child overrides", func(t *testing.T) {
+		parent := WithFields(context.Background(), map[string]any{"request": "r1", "layer": "http"})
+		child := WithFields(parent, map[string]any{"layer": "db"})
+
+		assert.Equal(t, map[string]any{"request": "r1", "layer": "http"}, contextFields(parent))
+		assert.Equal(t, map[string]any{"request": "r1", "layer": "db"}, contextFields(child))
+	})
+
+	t.Run("should merge context fields into GoCtx panic reports", func(t *testing.T) {
+		mock := &mockFieldLogger{}
+		SetLogger(mock)
+		defer SetLogger(&defaultLoggerImpl{})
+		SetGlobalFields(map[string]any{"service": "api", "layer": "global"})
+		defer SetGlobalFields(nil)
+
+		ctx := WithFields(context.Background(), map[string]any{"request": "r1", "layer": "db"})
+		GoCtx(ctx, func() { panic("ctx fields panic") })
+
+		assert.Eventually(t, func() bool {
+			calls, _ := mock.getFieldCalls()
+			return calls == 1
+		}, time.Second, time.Millisecond)
+		_, fields := mock.getFieldCalls()
+		assert.Equal(t, map[string]any{"service": "api", "request": "r1", "layer": "db"}, fields)
+	})
+
+	t.Run("should not be affected by later changes to the map", func(t *testing.T) {
+		fields := map[string]any{"request": "r1"}
+		ctx := WithFields(context.Background(), fields)
+		fields["request"] = "mutated"
+		assert.Equal(t, "r1", contextFields(ctx)["request"])
+	})
+}
@@ -0,0 +1,29 @@
+package tsafe
+
+import (
+	"context"
+	"runtime/debug"
+)
+
+// ContextLogger is implemented by loggers that take the context a goroutine was started with
+// When the configured logger implements it and a context is available, as with GoCtx,
+// PrintContext is called instead of the other Print methods, e.g. to extract trace ids
+type ContextLogger interface {
+	// PrintContext logs an error and its stack trace together with the goroutine's context
+	// and the merged fields, which may be nil
+	PrintContext(ctx context.Context, err, stack any, fields map[string]any)
+}
+
+// GoCtx starts a goroutine with automatic panic recovery like Go and routes ctx to the logger
+// A panic is logged through PrintContext if the configured logger implements ContextLogger,
+// otherwise exactly like Go. ctx is only used for logging: goroutine does not receive it and
+// its cancellation has no effect, so logging context works without changing function signatures
+// Fields attached to ctx with WithFields are merged into the report over the global fields
+func GoCtx(ctx context.Context, goroutine func()) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	GoWithRecover(goroutine, func(err any) {
+		reportPanicContext(ctx, err, debug.Stack(), contextFields(ctx))
+	})
+}
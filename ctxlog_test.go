@@ -0,0 +1,75 @@
+package tsafe
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// traceKey is a context key used by the context logger tests
+type traceKey struct{}
+
+// mockContextLogger records the context passed with each panic
+type mockContextLogger struct {
+	mockLogger
+	ctxMutex   sync.Mutex
+	lastCtx    context.Context
+	lastFields map[string]any
+}
+
+func (m *mockContextLogger) PrintContext(ctx context.Context, err, stack any, fields map[string]any) {
+	m.ctxMutex.Lock()
+	m.lastCtx = ctx
+	m.lastFields = fields
+	m.ctxMutex.Unlock()
+	m.Print(err, stack)
+}
+
+func (m *mockContextLogger) getLastContext() context.Context {
+	m.ctxMutex.Lock()
+	defer m.ctxMutex.Unlock()
+	return m.lastCtx
+}
+
+func (m *mockContextLogger) getLastFields() map[string]any {
+	m.ctxMutex.Lock()
+	defer m.ctxMutex.Unlock()
+	return m.lastFields
+}
+
+func TestGoCtx(t *testing.T) {
+	t.Run("should route the context to a ContextLogger", func(t *testing.T) {
+		mock := &mockContextLogger{}
+		SetLogger(mock)
+		defer SetLogger(&defaultLoggerImpl{})
+
+		ctx := context.WithValue(context.Background(), traceKey{}, "trace-1")
+		GoCtx(ctx, func() { panic("ctx panic") })
+
+		assert.Eventually(t, func() bool { return mock.getCallCount() == 1 }, time.Second, time.Millisecond)
+		assert.Equal(t, "trace-1", mock.getLastContext().Value(traceKey{}))
+		assert.Equal(t, "ctx panic", mock.getLastError())
+	})
+
+	t.Run("should fall back to Print for other loggers", func(t *testing.T) {
+		mock := &mockLogger{}
+		SetLogger(mock)
+		defer SetLogger(&defaultLoggerImpl{})
+
+		GoCtx(context.Background(), func() { panic("plain panic") })
+		assert.Eventually(t, func() bool { return mock.getCallCount() == 1 }, time.Second, time.Millisecond)
+	})
+
+	t.Run("should not use PrintContext without a context", func(t *testing.T) {
+		mock := &mockContextLogger{}
+		SetLogger(mock)
+		defer SetLogger(&defaultLoggerImpl{})
+
+		Go(func() { panic("no ctx panic") })
+		assert.Eventually(t, func() bool { return mock.getCallCount() == 1 }, time.Second, time.Millisecond)
+		assert.Nil(t, mock.getLastContext())
+	})
+}
@@ -0,0 +1,35 @@
+package tsafe
+
+import "sync"
+
+// Thread-safe dead-letter sink management
+var (
+	deadLetterSink  func(event PanicEvent)
+	deadLetterMutex sync.RWMutex
+)
+
+// SetDeadLetterSink sets a last-resort receiver for panic events the normal path failed to
+// deliver, i.e. events whose Emit on a registered sink or whose call into the configured
+// logger panicked. The event is passed once per failed delivery, unchanged
+// The dead-letter sink itself is called defensively: a panic in it is swallowed
+// Passing nil removes it, so failed deliveries are dropped again
+// This function is thread-safe
+func SetDeadLetterSink(sink func(event PanicEvent)) {
+	deadLetterMutex.Lock()
+	defer deadLetterMutex.Unlock()
+	deadLetterSink = sink
+}
+
+// getDeadLetterSink returns the current dead-letter sink in a thread-safe manner
+func getDeadLetterSink() func(event PanicEvent) {
+	deadLetterMutex.RLock()
+	defer deadLetterMutex.RUnlock()
+	return deadLetterSink
+}
+
+// deadLetter hands an undeliverable event to the dead-letter sink, if any
+func deadLetter(event PanicEvent) {
+	if sink := getDeadLetterSink(); sink != nil {
+		callSafely(func() { sink(event) })
+	}
+}
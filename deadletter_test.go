@@ -0,0 +1,89 @@
+package tsafe
+
+import (
+	"runtime/debug"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// deadLetters collects the events passed to the dead-letter sink
+type deadLetters struct {
+	mu     sync.Mutex
+	events []PanicEvent
+}
+
+func (d *deadLetters) add(event PanicEvent) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.events = append(d.events, event)
+}
+
+func (d *deadLetters) count() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.events)
+}
+
+func TestSetDeadLetterSink(t *testing.T) {
+	defer SetDeadLetterSink(nil)
+
+	t.Run("should capture events a panicking logger failed to log", func(t *testing.T) {
+		letters := &deadLetters{}
+		SetDeadLetterSink(letters.add)
+		SetLogger(panickingLogger{})
+		defer SetLogger(&defaultLoggerImpl{})
+
+		Go(func() { panic("lost") })
+		assert.Eventually(t, func() bool { return letters.count() == 1 }, time.Second, time.Millisecond)
+		assert.Equal(t, "lost", letters.events[0].Value)
+	})
+
+	t.Run("should capture events a panicking sink failed to receive", func(t *testing.T) {
+		letters := &deadLetters{}
+		SetDeadLetterSink(letters.add)
+		SetLogger(&mockLogger{})
+		defer SetLogger(&defaultLoggerImpl{})
+		defer RegisterSink(panickingSink{})()
+
+		Go(func() { panic("lost") })
+		assert.Eventually(t, func() bool { return letters.count() == 1 }, time.Second, time.Millisecond)
+	})
+
+	t.Run("should capture warnings a panicking logger failed to log", func(t *testing.T) {
+		letters := &deadLetters{}
+		SetDeadLetterSink(letters.add)
+		SetLogger(panickingLogger{})
+		defer SetLogger(&defaultLoggerImpl{})
+
+		logWarning(ErrShortRun, debug.Stack())
+		if assert.Equal(t, 1, letters.count()) {
+			assert.Equal(t, ErrShortRun, letters.events[0].Value)
+			assert.Equal(t, LevelWarn, letters.events[0].Level)
+		}
+	})
+
+	t.Run("should not capture delivered events", func(t *testing.T) {
+		letters := &deadLetters{}
+		SetDeadLetterSink(letters.add)
+		mock := &mockLogger{}
+		SetLogger(mock)
+		defer SetLogger(&defaultLoggerImpl{})
+
+		Go(func() { panic("delivered") })
+		assert.Eventually(t, func() bool { return mock.getCallCount() == 1 }, time.Second, time.Millisecond)
+		assert.Equal(t, 0, letters.count())
+	})
+
+	t.Run("should survive a panicking dead-letter sink", func(t *testing.T) {
+		SetDeadLetterSink(func(PanicEvent) { panic("last resort failed") })
+		SetLogger(panickingLogger{})
+		defer SetLogger(&defaultLoggerImpl{})
+
+		done := make(chan struct{})
+		GoWithRecoverAndLog(func() { panic("lost") }, func(any) { close(done) })
+		<-done
+	})
+}
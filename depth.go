@@ -0,0 +1,50 @@
+package tsafe
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// depthKey is the context key carrying the spawn depth
+type depthKey struct{}
+
+// maxSpawnDepth limits how deep GoNested spawns new goroutines; 0 means unlimited
+var maxSpawnDepth int64
+
+// SetMaxSpawnDepth bounds goroutine growth of recursive workloads started with GoNested
+// Once a chain of GoNested calls is n goroutines deep, deeper calls run synchronously in the
+// calling goroutine instead of spawning. A value of 0 means unlimited (the default)
+// This function is thread-safe
+func SetMaxSpawnDepth(n int) {
+	if n < 0 {
+		n = 0
+	}
+	atomic.StoreInt64(&maxSpawnDepth, int64(n))
+}
+
+// SpawnDepth returns how many GoNested goroutines deep ctx was created, 0 outside of any
+func SpawnDepth(ctx context.Context) int {
+	depth, _ := ctx.Value(depthKey{}).(int)
+	return depth
+}
+
+// GoNested starts fn with automatic panic recovery, handing it a context one level deeper than ctx
+// Since Go has no goroutine-local storage, the depth travels in the context: recursive
+// spawners must pass the context they receive to their nested GoNested calls
+// When the depth limit set by SetMaxSpawnDepth is reached, fn runs synchronously, still with
+// panic recovery and logging, so recursion keeps working with a bounded number of goroutines
+func GoNested(ctx context.Context, fn func(ctx context.Context)) {
+	if fn == nil {
+		return // Avoid creating goroutine for nil function
+	}
+	depth := SpawnDepth(ctx)
+	child := context.WithValue(ctx, depthKey{}, depth+1)
+
+	if limit := atomic.LoadInt64(&maxSpawnDepth); limit > 0 && int64(depth) >= limit {
+		if pe := runRecovered(func() { fn(child) }); pe != nil {
+			reportPanic(pe.Value, pe.Stack, nil)
+		}
+		return
+	}
+	Go(func() { fn(child) })
+}
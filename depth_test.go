@@ -0,0 +1,86 @@
+package tsafe
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoNested(t *testing.T) {
+	t.Run("should carry the depth in the context", func(t *testing.T) {
+		depths := make(chan int, 3)
+		GoNested(context.Background(), func(ctx context.Context) {
+			depths <- SpawnDepth(ctx)
+			GoNested(ctx, func(ctx context.Context) {
+				depths <- SpawnDepth(ctx)
+			})
+		})
+		assert.Equal(t, 1, <-depths)
+		assert.Equal(t, 2, <-depths)
+		assert.Equal(t, 0, SpawnDepth(context.Background()))
+	})
+
+	t.Run("should run synchronously beyond the maximum depth", func(t *testing.T) {
+		SetMaxSpawnDepth(2)
+		defer SetMaxSpawnDepth(0)
+
+		var (
+			wg      sync.WaitGroup
+			spawned int32
+			inline  int32
+		)
+		var walk func(ctx context.Context, level int)
+		walk = func(ctx context.Context, level int) {
+			if level == 0 {
+				return
+			}
+			for i := 0; i < 2; i++ {
+				wg.Add(1)
+				returned := false
+				GoNested(ctx, func(ctx context.Context) {
+					defer wg.Done()
+					if SpawnDepth(ctx) > 2 {
+						// Only synchronous calls run before GoNested returns
+						assert.False(t, returned)
+						atomic.AddInt32(&inline, 1)
+					} else {
+						atomic.AddInt32(&spawned, 1)
+					}
+					walk(ctx, level-1)
+				})
+				if SpawnDepth(ctx) >= 2 {
+					returned = true
+				}
+			}
+		}
+		walk(context.Background(), 4)
+		wg.Wait()
+		assert.Equal(t, int32(2+4), atomic.LoadInt32(&spawned))
+		assert.Equal(t, int32(8+16), atomic.LoadInt32(&inline))
+	})
+
+	t.Run("should recover panics of synchronous calls", func(t *testing.T) {
+		logger := &mockLogger{}
+		SetLogger(logger)
+		defer SetLogger(&defaultLoggerImpl{})
+		SetMaxSpawnDepth(1)
+		defer SetMaxSpawnDepth(0)
+
+		done := make(chan struct{})
+		GoNested(context.Background(), func(ctx context.Context) {
+			defer close(done)
+			GoNested(ctx, func(ctx context.Context) { panic("deep panic") })
+		})
+
+		select {
+		case <-done:
+			assert.Equal(t, 1, logger.getCallCount())
+		case <-time.After(time.Second):
+			t.Fatal("nested call did not run")
+		}
+	})
+}
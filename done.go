@@ -0,0 +1,77 @@
+package tsafe
+
+import (
+	"context"
+	"reflect"
+	"sync"
+)
+
+// GoDone starts a goroutine with automatic panic recovery and returns a channel that is
+// closed once it finishes, whether it returns normally, panics or calls runtime.Goexit
+// On panic the channel is closed after the panic has been logged, so receivers observe
+// the logged failure. It is closed exactly once, and also when the function never runs, e.g. when it is dropped after shutdown
+func GoDone(goroutine func()) <-chan struct{} {
+	done := make(chan struct{})
+	if goroutine == nil {
+		close(done)
+		return done
+	}
+
+	var once sync.Once
+	closeDone := func() { once.Do(func() { close(done) }) }
+	// The inner runWithRecover logs a panic before the deferred close runs, and the deferred
+	// close also covers runtime.Goexit, which never reaches a recover callback
+	goWithRecover(func() {
+		defer closeDone()
+		runWithRecover(goroutine, logPanic)
+	}, func(err any) {
+		defer closeDone()
+		logPanic(err)
+	}, closeDone)
+	return done
+}
+
+// WaitAllDone blocks until every channel is closed
+func WaitAllDone(chans ...<-chan struct{}) {
+	_ = WaitAllDoneContext(context.Background(), chans...)
+}
+
+// WaitAllDoneContext blocks until every channel is closed or ctx is done
+// It returns ctx.Err() if ctx ended first, otherwise nil
+func WaitAllDoneContext(ctx context.Context, chans ...<-chan struct{}) error {
+	for _, ch := range chans {
+		select {
+		case <-ch:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// WaitAnyDone blocks until one of the channels is closed and returns its index
+// If several are closed the lowest index is not guaranteed. Without channels it returns -1 immediately
+func WaitAnyDone(chans ...<-chan struct{}) int {
+	i, _ := WaitAnyDoneContext(context.Background(), chans...)
+	return i
+}
+
+// WaitAnyDoneContext blocks until one of the channels is closed or ctx is done
+// It returns the index of the closed channel, or -1 and ctx.Err() if ctx ended first
+// Without channels it returns -1 and a nil error immediately
+func WaitAnyDoneContext(ctx context.Context, chans ...<-chan struct{}) (int, error) {
+	if len(chans) == 0 {
+		return -1, nil
+	}
+	cases := make([]reflect.SelectCase, len(chans)+1)
+	for i, ch := range chans {
+		cases[i] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ch)}
+	}
+	cases[len(chans)] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())}
+
+	chosen, _, _ := reflect.Select(cases)
+	if chosen == len(chans) {
+		return -1, ctx.Err()
+	}
+	return chosen, nil
+}
@@ -0,0 +1,113 @@
+package tsafe
+
+import (
+	"context"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoDone(t *testing.T) {
+	t.Run("should close the channel on normal completion", func(t *testing.T) {
+		var ran int32
+		done := GoDone(func() {
+			atomic.StoreInt32(&ran, 1)
+		})
+
+		select {
+		case <-done:
+			assert.Equal(t, int32(1), atomic.LoadInt32(&ran))
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("done channel was not closed")
+		}
+	})
+
+	t.Run("should close the channel after a logged panic", func(t *testing.T) {
+		mock := &mockLogger{}
+		originalLogger := getLogger()
+		SetLogger(mock)
+		defer SetLogger(originalLogger)
+
+		done := GoDone(func() {
+			panic("done panic")
+		})
+
+		select {
+		case <-done:
+			assert.Equal(t, 1, mock.getCallCount())
+			assert.Equal(t, "done panic", mock.getLastError())
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("done channel was not closed")
+		}
+	})
+
+	t.Run("should close the channel when the function calls runtime.Goexit", func(t *testing.T) {
+		done := GoDone(func() {
+			runtime.Goexit()
+		})
+
+		select {
+		case <-done:
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("done channel was not closed")
+		}
+	})
+
+	t.Run("should close the channel when dropped after shutdown", func(t *testing.T) {
+		SetLogger(&mockLogger{})
+		defer SetLogger(&defaultLoggerImpl{})
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		SetShutdownContext(ctx)
+		defer SetShutdownContext(nil)
+
+		select {
+		case <-GoDone(func() {}):
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("done channel was not closed")
+		}
+	})
+
+	t.Run("should return a closed channel for nil function", func(t *testing.T) {
+		_, open := <-GoDone(nil)
+		assert.False(t, open)
+	})
+}
+
+func TestWaitDone(t *testing.T) {
+	t.Run("should wait for all channels", func(t *testing.T) {
+		var finished int32
+		chans := make([]<-chan struct{}, 3)
+		for i := range chans {
+			chans[i] = GoDone(func() {
+				time.Sleep(5 * time.Millisecond)
+				atomic.AddInt32(&finished, 1)
+			})
+		}
+		WaitAllDone(chans...)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&finished))
+	})
+
+	t.Run("should return the index of the first closed channel", func(t *testing.T) {
+		block := make(chan struct{})
+		defer close(block)
+		fast := GoDone(func() {})
+		assert.Equal(t, 1, WaitAnyDone(block, fast))
+		assert.Equal(t, -1, WaitAnyDone())
+	})
+
+	t.Run("should return early when the context ends", func(t *testing.T) {
+		block := make(chan struct{})
+		defer close(block)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		assert.ErrorIs(t, WaitAllDoneContext(ctx, GoDone(func() {}), block), context.DeadlineExceeded)
+		i, err := WaitAnyDoneContext(ctx, block)
+		assert.Equal(t, -1, i)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
@@ -0,0 +1,122 @@
+package tsafe
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"strings"
+)
+
+// ErrPanic is the sentinel matched by every PanicError
+// Use errors.Is(err, tsafe.ErrPanic) to detect that an error originated from a recovered panic
+var ErrPanic = errors.New("tsafe: goroutine panicked")
+
+// ErrGoexit is returned to callers waiting on a shared computation whose function called
+// runtime.Goexit, e.g. through t.FailNow, instead of returning a result
+var ErrGoexit = errors.New("tsafe: function called runtime.Goexit")
+
+// PanicError wraps a recovered panic value together with the stack trace captured at recovery
+// It integrates with the standard errors package:
+//   - errors.Is(err, ErrPanic) reports true for any PanicError
+//   - errors.As(err, &pe) extracts the *PanicError from a wrapped chain
+//   - errors.Unwrap returns the panic value when it is itself an error
+type PanicError struct {
+	// Value is the value passed to panic
+	Value any
+	// Stack is the stack trace captured when the panic was recovered
+	Stack []byte
+}
+
+// newPanicError builds a PanicError from a recovered value and its stack
+func newPanicError(value any, stack []byte) *PanicError {
+	return &PanicError{Value: value, Stack: stack}
+}
+
+// Error implements the error interface
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Unwrap returns the panic value if it is an error, allowing errors.Is/As to reach it
+func (e *PanicError) Unwrap() error {
+	if err, ok := e.Value.(error); ok {
+		return err
+	}
+	return nil
+}
+
+// Is reports whether target is the ErrPanic sentinel
+func (e *PanicError) Is(target error) bool {
+	return target == ErrPanic
+}
+
+// MultiPanicError collects every panic raised while a single goroutine unwound,
+// such as a panic in the main function followed by a panic in a cleanup function
+// Panics are ordered by occurrence so the original failure comes first
+// errors.Is(err, ErrPanic) reports true on every Go version, but errors.Is and errors.As only
+// descend into the collected panics with Go 1.20 or newer, which added multi-error unwrapping;
+// on Go 1.18 and 1.19 they do not see the individual panics, so range over Panics instead
+type MultiPanicError struct {
+	Panics []*PanicError
+}
+
+// Error implements the error interface
+func (e *MultiPanicError) Error() string {
+	msgs := make([]string, len(e.Panics))
+	for i, p := range e.Panics {
+		msgs[i] = p.Error()
+	}
+	return fmt.Sprintf("%d panics: %s", len(e.Panics), strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the collected panics for multi-error aware inspection, used by the errors
+// package from Go 1.20
+func (e *MultiPanicError) Unwrap() []error {
+	errs := make([]error, len(e.Panics))
+	for i, p := range e.Panics {
+		errs[i] = p
+	}
+	return errs
+}
+
+// Is reports whether target is the ErrPanic sentinel
+func (e *MultiPanicError) Is(target error) bool {
+	return target == ErrPanic
+}
+
+// Stack returns the stacks of all collected panics joined in order
+func (e *MultiPanicError) Stack() []byte {
+	stacks := make([][]byte, len(e.Panics))
+	for i, p := range e.Panics {
+		stacks[i] = p.Stack
+	}
+	return bytes.Join(stacks, []byte("\n--- next panic ---\n"))
+}
+
+// runRecovered executes fn in the current goroutine and returns the recovered panic, if any
+// A panic(nil) is reported with a *NilPanicError value, while runtime.Goexit is not treated as a panic
+func runRecovered(fn func()) *PanicError {
+	var (
+		completed bool
+		value     any
+		stack     []byte
+	)
+	func() {
+		defer func() {
+			if completed {
+				return
+			}
+			value = recover()
+			stack = debug.Stack()
+		}()
+		fn()
+		completed = true
+	}()
+	// runtime.Goexit never returns here, so an incomplete fn means a recovered panic
+	if completed {
+		return nil
+	}
+	countPanic(stack)
+	return newPanicError(normalizePanicValue(value), stack)
+}
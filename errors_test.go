@@ -0,0 +1,47 @@
+package tsafe
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPanicError(t *testing.T) {
+	t.Run("should format the panic value", func(t *testing.T) {
+		pe := newPanicError("boom", []byte("stack"))
+		assert.Equal(t, "panic: boom", pe.Error())
+		assert.Equal(t, []byte("stack"), pe.Stack)
+	})
+
+	t.Run("should match ErrPanic with errors.Is", func(t *testing.T) {
+		var err error = newPanicError("boom", nil)
+		assert.True(t, errors.Is(err, ErrPanic))
+
+		wrapped := fmt.Errorf("task failed: %w", err)
+		assert.True(t, errors.Is(wrapped, ErrPanic))
+		assert.False(t, errors.Is(errors.New("other"), ErrPanic))
+	})
+
+	t.Run("should extract PanicError with errors.As", func(t *testing.T) {
+		wrapped := fmt.Errorf("task failed: %w", newPanicError(42, nil))
+
+		var pe *PanicError
+		assert.True(t, errors.As(wrapped, &pe))
+		assert.Equal(t, 42, pe.Value)
+	})
+
+	t.Run("should unwrap error panic values", func(t *testing.T) {
+		pe := newPanicError(io.EOF, nil)
+		assert.Equal(t, io.EOF, errors.Unwrap(pe))
+		assert.True(t, errors.Is(pe, io.EOF))
+		assert.True(t, errors.Is(pe, ErrPanic))
+	})
+
+	t.Run("should unwrap to nil for non-error values", func(t *testing.T) {
+		pe := newPanicError("boom", nil)
+		assert.Nil(t, pe.Unwrap())
+	})
+}
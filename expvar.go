@@ -0,0 +1,26 @@
+package tsafe
+
+import (
+	"expvar"
+	"sync"
+	"sync/atomic"
+)
+
+// expvarName is the name of the expvar map holding the tsafe counters
+const expvarName = "tsafe"
+
+var publishOnce sync.Once
+
+// PublishExpvar publishes the package statistics as the expvar map "tsafe" with the keys
+// total_spawned, total_panics and active_goroutines, so they show up on /debug/vars
+// The values are read from the same counters as Stats each time the map is rendered
+// Nothing is registered unless PublishExpvar is called; calling it again has no effect
+// This function is thread-safe
+func PublishExpvar() {
+	publishOnce.Do(func() {
+		m := expvar.NewMap(expvarName)
+		m.Set("total_spawned", expvar.Func(func() any { return atomic.LoadUint64(&statsSpawned) }))
+		m.Set("total_panics", expvar.Func(func() any { return atomic.LoadUint64(&statsPanics) }))
+		m.Set("active_goroutines", expvar.Func(func() any { return atomic.LoadInt64(&statsActive) }))
+	})
+}
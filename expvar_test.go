@@ -0,0 +1,38 @@
+package tsafe
+
+import (
+	"encoding/json"
+	"expvar"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublishExpvar(t *testing.T) {
+	t.Run("should publish the stats counters once", func(t *testing.T) {
+		PublishExpvar()
+		assert.NotPanics(t, PublishExpvar)
+
+		v := expvar.Get("tsafe")
+		if !assert.NotNil(t, v) {
+			return
+		}
+		var published map[string]int64
+		assert.NoError(t, json.Unmarshal([]byte(v.String()), &published))
+		assert.Contains(t, published, "total_spawned")
+		assert.Contains(t, published, "total_panics")
+		assert.Contains(t, published, "active_goroutines")
+	})
+
+	t.Run("should reflect the current counters", func(t *testing.T) {
+		PublishExpvar()
+		SetLogger(&mockLogger{})
+		defer SetLogger(&defaultLoggerImpl{})
+
+		<-GoDone(func() { panic("expvar panic") })
+
+		var published map[string]int64
+		assert.NoError(t, json.Unmarshal([]byte(expvar.Get("tsafe").String()), &published))
+		assert.Equal(t, int64(Stats().TotalPanics), published["total_panics"])
+	})
+}
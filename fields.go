@@ -0,0 +1,80 @@
+package tsafe
+
+import (
+	"fmt"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// FieldLogger is implemented by loggers that accept structured fields alongside a panic
+// When the configured logger implements it and fields are present, PrintFields is called instead of Print
+type FieldLogger interface {
+	// PrintFields logs an error and its stack trace together with structured fields
+	PrintFields(err, stack any, fields map[string]any)
+}
+
+// Thread-safe global fields management
+var (
+	globalFields map[string]any
+	fieldsMutex  sync.RWMutex
+)
+
+// SetGlobalFields sets fields merged into every panic report, such as service name, version or hostname
+// The map is copied, so later changes to it have no effect; per-goroutine fields win on key conflicts
+// Passing nil clears the global fields
+// This function is thread-safe
+func SetGlobalFields(fields map[string]any) {
+	copied := mergeFields(fields, nil)
+	fieldsMutex.Lock()
+	defer fieldsMutex.Unlock()
+	globalFields = copied
+}
+
+// getGlobalFields returns the current global fields in a thread-safe manner
+// The returned map must not be modified
+func getGlobalFields() map[string]any {
+	fieldsMutex.RLock()
+	defer fieldsMutex.RUnlock()
+	return globalFields
+}
+
+// GoWithFields starts a goroutine with automatic panic recovery whose panic report carries fields
+// Fields are delivered to loggers implementing FieldLogger, merged over the global fields
+func GoWithFields(fields map[string]any, goroutine func()) {
+	fields = mergeFields(fields, nil)
+	GoWithRecover(goroutine, func(err any) {
+		reportPanic(err, debug.Stack(), fields)
+	})
+}
+
+// mergeFields returns a new map holding base overridden by override, or nil if both are empty
+func mergeFields(base, override map[string]any) map[string]any {
+	if len(base) == 0 && len(override) == 0 {
+		return nil
+	}
+	merged := make(map[string]any, len(base)+len(override))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range override {
+		merged[k] = v
+	}
+	return merged
+}
+
+// formatFields renders fields as space separated key=value pairs sorted by key
+func formatFields(fields map[string]any) string {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		pairs[i] = fmt.Sprintf("%s=%v", k, fields[k])
+	}
+	return strings.Join(pairs, " ")
+}
@@ -0,0 +1,117 @@
+package tsafe
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// mockFieldLogger records the fields passed with each panic
+type mockFieldLogger struct {
+	mockLogger
+	lastFields map[string]any
+	fieldCalls int
+	fieldMutex sync.Mutex
+}
+
+func (m *mockFieldLogger) PrintFields(err, stack any, fields map[string]any) {
+	m.fieldMutex.Lock()
+	defer m.fieldMutex.Unlock()
+	m.lastFields = fields
+	m.fieldCalls++
+}
+
+func (m *mockFieldLogger) getFieldCalls() (int, map[string]any) {
+	m.fieldMutex.Lock()
+	defer m.fieldMutex.Unlock()
+	return m.fieldCalls, m.lastFields
+}
+
+func TestSetGlobalFields(t *testing.T) {
+	t.Run("should merge global and per-goroutine fields", func(t *testing.T) {
+		mock := &mockFieldLogger{}
+		originalLogger := getLogger()
+		SetLogger(mock)
+		defer SetLogger(originalLogger)
+
+		global := map[string]any{"service": "api", "version": "1.0"}
+		SetGlobalFields(global)
+		defer SetGlobalFields(nil)
+		global["service"] = "mutated" // Changes after SetGlobalFields must not leak in
+
+		GoWithFields(map[string]any{"version": "2.0", "task": "sync"}, func() {
+			panic("fields panic")
+		})
+
+		assert.Eventually(t, func() bool {
+			calls, _ := mock.getFieldCalls()
+			return calls == 1
+		}, 100*time.Millisecond, time.Millisecond)
+
+		_, fields := mock.getFieldCalls()
+		assert.Equal(t, map[string]any{"service": "api", "version": "2.0", "task": "sync"}, fields)
+		assert.Equal(t, 0, mock.getCallCount())
+	})
+
+	t.Run("should apply global fields to Go", func(t *testing.T) {
+		mock := &mockFieldLogger{}
+		originalLogger := getLogger()
+		SetLogger(mock)
+		defer SetLogger(originalLogger)
+
+		SetGlobalFields(map[string]any{"host": "node-1"})
+		defer SetGlobalFields(nil)
+
+		Go(func() {
+			panic("global fields panic")
+		})
+
+		assert.Eventually(t, func() bool {
+			calls, _ := mock.getFieldCalls()
+			return calls == 1
+		}, 100*time.Millisecond, time.Millisecond)
+		_, fields := mock.getFieldCalls()
+		assert.Equal(t, map[string]any{"host": "node-1"}, fields)
+	})
+
+	t.Run("should fall back to Print for plain loggers", func(t *testing.T) {
+		mock := &mockLogger{}
+		originalLogger := getLogger()
+		SetLogger(mock)
+		defer SetLogger(originalLogger)
+
+		SetGlobalFields(map[string]any{"host": "node-1"})
+		defer SetGlobalFields(nil)
+
+		GoWithFields(map[string]any{"task": "sync"}, func() {
+			panic("plain panic")
+		})
+		assert.Eventually(t, func() bool {
+			return mock.getCallCount() == 1
+		}, 100*time.Millisecond, time.Millisecond)
+	})
+
+	t.Run("should use Print when there are no fields", func(t *testing.T) {
+		mock := &mockFieldLogger{}
+		originalLogger := getLogger()
+		SetLogger(mock)
+		defer SetLogger(originalLogger)
+		SetGlobalFields(nil)
+
+		Go(func() {
+			panic("no fields")
+		})
+		assert.Eventually(t, func() bool {
+			return mock.getCallCount() == 1
+		}, 100*time.Millisecond, time.Millisecond)
+		calls, _ := mock.getFieldCalls()
+		assert.Equal(t, 0, calls)
+	})
+}
+
+func TestFormatFields(t *testing.T) {
+	assert.Equal(t, "a=1 b=two", formatFields(map[string]any{"b": "two", "a": 1}))
+	assert.Equal(t, "", formatFields(nil))
+}
@@ -0,0 +1,49 @@
+package tsafe
+
+import (
+	"context"
+	"fmt"
+)
+
+// FlushAll flushes the configured logger and every registered sink implementing Flusher,
+// e.g. from a shutdown hook so no panic telemetry buffered by async components is lost
+// All flushers run concurrently. FlushAll returns the first error a flusher reported, a
+// flusher's panic as a *PanicError, or ctx.Err() if some did not finish before ctx ended
+// This function is thread-safe
+func FlushAll(ctx context.Context) error {
+	var flushers []Flusher
+	if f, ok := getLogger().(Flusher); ok {
+		flushers = append(flushers, f)
+	}
+	for _, e := range getSinks() {
+		if f, ok := e.sink.(Flusher); ok {
+			flushers = append(flushers, f)
+		}
+	}
+
+	results := make(chan error, len(flushers))
+	for _, f := range flushers {
+		f := f
+		// Flushing is infrastructure rather than user work, so it is not tracked by Stats
+		go func() {
+			var err error
+			if v := callSafely(func() { err = f.Flush() }); v != nil {
+				err = newPanicError(v, nil)
+			}
+			results <- err
+		}()
+	}
+
+	var first error
+	for range flushers {
+		select {
+		case err := <-results:
+			if err != nil && first == nil {
+				first = err
+			}
+		case <-ctx.Done():
+			return fmt.Errorf("tsafe: flush incomplete: %w", ctx.Err())
+		}
+	}
+	return first
+}
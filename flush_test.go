@@ -0,0 +1,82 @@
+package tsafe
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// flusherSink is a Sink implementing Flusher
+type flusherSink struct {
+	flush func() error
+}
+
+func (s flusherSink) Emit(event PanicEvent) {}
+
+func (s flusherSink) Flush() error {
+	return s.flush()
+}
+
+func TestFlushAll(t *testing.T) {
+	t.Run("should flush async loggers registered as sinks", func(t *testing.T) {
+		inner := &mockLogger{}
+		async := NewAsyncLogger(inner, 10)
+		defer async.Close()
+		unregister := RegisterSink(NewLoggerSink(async))
+		defer unregister()
+
+		async.Print("boom", "stack")
+		assert.NoError(t, FlushAll(context.Background()))
+		assert.Equal(t, 1, inner.getCallCount())
+	})
+
+	t.Run("should flush the configured logger", func(t *testing.T) {
+		inner := &mockLogger{}
+		async := NewAsyncLogger(inner, 10)
+		defer async.Close()
+		SetLogger(async)
+		defer SetLogger(&defaultLoggerImpl{})
+
+		async.Print("boom", "stack")
+		assert.NoError(t, FlushAll(context.Background()))
+		assert.Equal(t, 1, inner.getCallCount())
+	})
+
+	t.Run("should return the error of a failing flusher", func(t *testing.T) {
+		flushErr := errors.New("disk full")
+		unregister := RegisterSink(flusherSink{flush: func() error { return flushErr }})
+		defer unregister()
+
+		assert.ErrorIs(t, FlushAll(context.Background()), flushErr)
+	})
+
+	t.Run("should return a PanicError when a flusher panics", func(t *testing.T) {
+		unregister := RegisterSink(flusherSink{flush: func() error { panic("flush boom") }})
+		defer unregister()
+
+		var pe *PanicError
+		assert.ErrorAs(t, FlushAll(context.Background()), &pe)
+		assert.Equal(t, "flush boom", pe.Value)
+	})
+
+	t.Run("should give up when the context ends first", func(t *testing.T) {
+		release := make(chan struct{})
+		defer close(release)
+		unregister := RegisterSink(flusherSink{flush: func() error {
+			<-release
+			return nil
+		}})
+		defer unregister()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(t, FlushAll(ctx), context.DeadlineExceeded)
+	})
+
+	t.Run("should succeed with nothing to flush", func(t *testing.T) {
+		assert.NoError(t, FlushAll(context.Background()))
+	})
+}
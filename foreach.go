@@ -0,0 +1,65 @@
+package tsafe
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// GoForEach processes items concurrently with at most concurrency workers
+// On the first error or panic the context passed to fn is canceled so remaining workers
+// stop early, and that first error is returned; panics are returned as *PanicError
+// Items not yet started when the context is canceled are skipped. If the parent context
+// ends before all items are processed, its error is returned
+// GoForEach returns only after every worker has exited, so no goroutines are leaked
+func GoForEach[T any](ctx context.Context, items []T, concurrency int, fn func(ctx context.Context, item T) error) error {
+	if len(items) == 0 {
+		return ctx.Err()
+	}
+	if concurrency <= 0 || concurrency > len(items) {
+		concurrency = len(items)
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		next     int64
+		errOnce  sync.Once
+		firstErr error
+	)
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		spawn(func() {
+			defer wg.Done()
+			for runCtx.Err() == nil {
+				i := int(atomic.AddInt64(&next, 1) - 1)
+				if i >= len(items) {
+					return
+				}
+				if err := callForEach(runCtx, items[i], fn); err != nil {
+					errOnce.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					return
+				}
+			}
+		})
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
+
+// callForEach runs fn for a single item and converts a panic into a *PanicError
+func callForEach[T any](ctx context.Context, item T, fn func(ctx context.Context, item T) error) (err error) {
+	if pe := runRecovered(func() { err = fn(ctx, item) }); pe != nil {
+		return pe
+	}
+	return err
+}
@@ -0,0 +1,94 @@
+package tsafe
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoForEach(t *testing.T) {
+	t.Run("should process every item", func(t *testing.T) {
+		var sum int64
+		err := GoForEach(context.Background(), []int64{1, 2, 3, 4, 5}, 2, func(ctx context.Context, item int64) error {
+			atomic.AddInt64(&sum, item)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(15), atomic.LoadInt64(&sum))
+	})
+
+	t.Run("should stop early on the first error", func(t *testing.T) {
+		expected := errors.New("item failed")
+		var processed int32
+		items := make([]int, 100)
+		for i := range items {
+			items[i] = i
+		}
+
+		err := GoForEach(context.Background(), items, 2, func(ctx context.Context, item int) error {
+			atomic.AddInt32(&processed, 1)
+			if item == 3 {
+				return expected
+			}
+			select {
+			case <-ctx.Done():
+			case <-time.After(time.Millisecond):
+			}
+			return nil
+		})
+		assert.Equal(t, expected, err)
+		assert.Less(t, atomic.LoadInt32(&processed), int32(100))
+	})
+
+	t.Run("should convert panics into PanicError and cancel siblings", func(t *testing.T) {
+		started := make(chan struct{})
+		canceled := make(chan struct{})
+		err := GoForEach(context.Background(), []int{0, 1}, 2, func(ctx context.Context, item int) error {
+			if item == 0 {
+				<-started
+				panic("foreach panic")
+			}
+			close(started)
+			<-ctx.Done()
+			close(canceled)
+			return nil
+		})
+
+		var pe *PanicError
+		if assert.True(t, errors.As(err, &pe)) {
+			assert.Equal(t, "foreach panic", pe.Value)
+		}
+		select {
+		case <-canceled:
+		default:
+			t.Fatal("sibling worker was not canceled")
+		}
+	})
+
+	t.Run("should not leak goroutines after early cancellation", func(t *testing.T) {
+		before := runtime.NumGoroutine()
+		_ = GoForEach(context.Background(), make([]int, 50), 10, func(ctx context.Context, item int) error {
+			return errors.New("fail fast")
+		})
+		// Poll manually since assert.Eventually runs its own goroutines
+		deadline := time.Now().Add(time.Second)
+		for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+		assert.LessOrEqual(t, runtime.NumGoroutine(), before)
+	})
+
+	t.Run("should return the parent context error", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := GoForEach(ctx, []int{1, 2, 3}, 1, func(ctx context.Context, item int) error {
+			return nil
+		})
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
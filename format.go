@@ -0,0 +1,97 @@
+package tsafe
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// LogFormat selects the output format of the built-in default logger
+type LogFormat int32
+
+// Output formats supported by the default logger
+const (
+	// FormatText writes human readable multi-line entries (the default)
+	FormatText LogFormat = iota
+	// FormatJSON writes one JSON object per panic with "time", "error", "stack", "causes" for
+	// wrapped errors, "memory" with SetIncludeMemStats, and any fields
+	FormatJSON
+)
+
+// defaultLoggerFormat is the LogFormat used by the default logger
+var defaultLoggerFormat int32
+
+// jsonMutex serializes JSON entries written directly to the log output
+var jsonMutex sync.Mutex
+
+// SetDefaultLoggerFormat changes the output format of the built-in default logger
+// It has no effect on custom loggers installed with SetLogger
+// This function is thread-safe
+func SetDefaultLoggerFormat(format LogFormat) {
+	atomic.StoreInt32(&defaultLoggerFormat, int32(format))
+}
+
+// getDefaultLoggerFormat returns the current default logger format
+func getDefaultLoggerFormat() LogFormat {
+	return LogFormat(atomic.LoadInt32(&defaultLoggerFormat))
+}
+
+// printJSON writes a panic as a single JSON line to the standard log output
+// The log prefix is bypassed so every line is a valid JSON object; fields never
+// override the reserved time, error, stack, causes and memory keys
+func printJSON(err, stack any, fields map[string]any) {
+	entry := make(map[string]any, len(fields)+3)
+	for k, v := range fields {
+		entry[k] = jsonValue(v)
+	}
+	entry["time"] = time.Now().Format(time.RFC3339Nano)
+	entry["error"] = fmt.Sprint(err)
+	entry["stack"] = fmt.Sprintf("%s", stack)
+	if causes := causeChain(err); causes != nil {
+		entry["causes"] = causes
+	}
+	if ms := currentMemStats(); ms != nil {
+		entry["memory"] = ms
+	}
+
+	line, marshalErr := json.Marshal(entry)
+	if marshalErr != nil {
+		line, _ = json.Marshal(map[string]any{
+			"time":  entry["time"],
+			"error": entry["error"],
+			"stack": entry["stack"],
+		})
+	}
+
+	jsonMutex.Lock()
+	defer jsonMutex.Unlock()
+	_, _ = log.Writer().Write(append(line, '\n'))
+}
+
+// jsonValue converts values without a JSON encoding, such as errors, to their string form
+func jsonValue(v any) any {
+	switch val := v.(type) {
+	case error:
+		return val.Error()
+	case fmt.Stringer:
+		return val.String()
+	}
+	if _, err := json.Marshal(v); err != nil {
+		return fmt.Sprint(v)
+	}
+	return v
+}
+
+// formatCauses renders a cause chain as "caused by:" lines, each preceded by a newline
+func formatCauses(causes []string) string {
+	var b strings.Builder
+	for _, cause := range causes {
+		b.WriteString("\ncaused by: ")
+		b.WriteString(cause)
+	}
+	return b.String()
+}
@@ -0,0 +1,110 @@
+package tsafe
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// captureLogOutput redirects the standard logger while fn runs and returns what was written
+func captureLogOutput(fn func()) string {
+	var buf bytes.Buffer
+	originalOutput := log.Writer()
+	log.SetOutput(&buf)
+	defer log.SetOutput(originalOutput)
+	fn()
+	return buf.String()
+}
+
+func TestSetDefaultLoggerFormat(t *testing.T) {
+	logger := &defaultLoggerImpl{}
+
+	t.Run("should write text by default", func(t *testing.T) {
+		out := captureLogOutput(func() {
+			logger.Print("text panic", []byte("stack"))
+		})
+		assert.Contains(t, out, "Error in goroutine: text panic")
+		assert.Contains(t, out, "Stack trace: stack")
+	})
+
+	t.Run("should write one JSON object per panic", func(t *testing.T) {
+		SetDefaultLoggerFormat(FormatJSON)
+		defer SetDefaultLoggerFormat(FormatText)
+
+		stack := "goroutine 1 [running]:\n\tmain.go:1 \"quoted\""
+		out := captureLogOutput(func() {
+			logger.PrintFields(errors.New("json panic"), []byte(stack), map[string]any{
+				"service": "api",
+				"cause":   errors.New("wrapped"),
+				"error":   "must not override",
+			})
+		})
+
+		lines := strings.Split(strings.TrimSpace(out), "\n")
+		assert.Len(t, lines, 1)
+
+		var entry map[string]any
+		if assert.NoError(t, json.Unmarshal([]byte(lines[0]), &entry)) {
+			assert.Equal(t, "json panic", entry["error"])
+			assert.Equal(t, stack, entry["stack"])
+			assert.Equal(t, "api", entry["service"])
+			assert.Equal(t, "wrapped", entry["cause"])
+			assert.NotEmpty(t, entry["time"])
+		}
+	})
+
+	t.Run("should encode values without a JSON form as strings", func(t *testing.T) {
+		SetDefaultLoggerFormat(FormatJSON)
+		defer SetDefaultLoggerFormat(FormatText)
+
+		out := captureLogOutput(func() {
+			logger.PrintFields("panic", "stack", map[string]any{"fn": func() {}})
+		})
+		var entry map[string]any
+		if assert.NoError(t, json.Unmarshal([]byte(out), &entry)) {
+			assert.IsType(t, "", entry["fn"])
+		}
+	})
+}
+
+func TestCauseChain(t *testing.T) {
+	root := errors.New("connection refused")
+	wrapped := fmt.Errorf("query users: %w", fmt.Errorf("dial db: %w", root))
+
+	t.Run("should walk wrapped errors outermost first", func(t *testing.T) {
+		assert.Equal(t, []string{"dial db: connection refused", "connection refused"}, causeChain(wrapped))
+		assert.Nil(t, causeChain(root))
+		assert.Nil(t, causeChain("not an error"))
+	})
+
+	t.Run("should include the chain in panic events", func(t *testing.T) {
+		event := newPanicEvent(wrapped, nil, nil, LevelError)
+		assert.Equal(t, []string{"dial db: connection refused", "connection refused"}, event.Causes)
+	})
+
+	t.Run("should render caused by lines in the default logger", func(t *testing.T) {
+		out := captureLogOutput(func() {
+			(&defaultLoggerImpl{}).Print(wrapped, []byte("stack"))
+		})
+		assert.Contains(t, out, "Error in goroutine: query users: dial db: connection refused\n"+
+			"caused by: dial db: connection refused\ncaused by: connection refused\nStack trace: stack")
+	})
+
+	t.Run("should add the chain to JSON entries", func(t *testing.T) {
+		SetDefaultLoggerFormat(FormatJSON)
+		defer SetDefaultLoggerFormat(FormatText)
+
+		out := captureLogOutput(func() {
+			(&defaultLoggerImpl{}).Print(wrapped, []byte("stack"))
+		})
+		var entry map[string]any
+		assert.NoError(t, json.Unmarshal([]byte(out), &entry))
+		assert.Equal(t, []any{"dial db: connection refused", "connection refused"}, entry["causes"])
+	})
+}
@@ -0,0 +1,45 @@
+package tsafe
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Thread-safe panic value formatter management
+var (
+	valueFormatter func(v any) string
+	formatterMutex sync.RWMutex
+)
+
+// DefaultValueFormatter formats a panic value with %v
+func DefaultValueFormatter(v any) string {
+	return fmt.Sprintf("%v", v)
+}
+
+// SetValueFormatter sets a function formatting every panic value centrally before it is passed to
+// the logger, so all loggers receive the same string, e.g. using %+v for errors carrying stacks or
+// redacting secrets. DefaultValueFormatter is a ready-made choice
+// Without a formatter, or when passing nil, loggers receive the raw value (the default)
+// Sinks always receive the raw value. A panicking formatter falls back to DefaultValueFormatter
+// This function is thread-safe
+func SetValueFormatter(f func(v any) string) {
+	formatterMutex.Lock()
+	defer formatterMutex.Unlock()
+	valueFormatter = f
+}
+
+// formatValue applies the configured value formatter, if any
+func formatValue(v any) any {
+	formatterMutex.RLock()
+	f := valueFormatter
+	formatterMutex.RUnlock()
+	if f == nil {
+		return v
+	}
+
+	formatted := ""
+	if callSafely(func() { formatted = f(v) }) != nil {
+		return DefaultValueFormatter(v)
+	}
+	return formatted
+}
@@ -0,0 +1,42 @@
+package tsafe
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetValueFormatter(t *testing.T) {
+	t.Run("should pass formatted values to the logger", func(t *testing.T) {
+		mock := &mockLogger{}
+		SetLogger(mock)
+		defer SetLogger(&defaultLoggerImpl{})
+		SetValueFormatter(func(v any) string { return fmt.Sprintf("redacted(%T)", v) })
+		defer SetValueFormatter(nil)
+
+		Go(func() { panic(errors.New("password=hunter2")) })
+		assert.Eventually(t, func() bool { return mock.getCallCount() == 1 }, time.Second, time.Millisecond)
+		assert.Equal(t, "redacted(*errors.errorString)", mock.getLastError())
+	})
+
+	t.Run("should pass raw values without a formatter", func(t *testing.T) {
+		mock := &mockLogger{}
+		SetLogger(mock)
+		defer SetLogger(&defaultLoggerImpl{})
+
+		Go(func() { panic(42) })
+		assert.Eventually(t, func() bool { return mock.getCallCount() == 1 }, time.Second, time.Millisecond)
+		assert.Equal(t, 42, mock.getLastError())
+	})
+
+	t.Run("should fall back when the formatter panics", func(t *testing.T) {
+		SetValueFormatter(func(v any) string { panic("formatter panic") })
+		defer SetValueFormatter(nil)
+
+		assert.Equal(t, "42", formatValue(42))
+		assert.Equal(t, "42", DefaultValueFormatter(42))
+	})
+}
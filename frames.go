@@ -0,0 +1,74 @@
+package tsafe
+
+import (
+	"runtime"
+	"sync/atomic"
+)
+
+// Frame is a single resolved stack frame
+type Frame struct {
+	// PC is the program counter of the call site
+	PC uintptr
+	// Function is the fully qualified function name
+	Function string
+	// File and Line locate the call site in the source
+	File string
+	Line int
+	// Inlined reports whether the compiler inlined this call into its caller
+	Inlined bool
+}
+
+// CaptureStack returns the calling goroutine's stack as resolved frames, innermost first
+// Unlike debug.Stack it uses runtime.CallersFrames, so calls the compiler inlined are
+// expanded into their own frames and marked as Inlined, giving precise locations in
+// optimized builds. The first frame is the caller of CaptureStack. Internal frames are
+// removed when SetTrimInternalFrames is enabled, like in logged stacks
+func CaptureStack() []Frame {
+	return captureStack(3) // runtime.Callers, captureStack and CaptureStack
+}
+
+// captureStack resolves the stack after skipping the given number of frames
+func captureStack(skip int) []Frame {
+	pcs := make([]uintptr, 64)
+	for {
+		n := runtime.Callers(skip, pcs)
+		if n < len(pcs) {
+			pcs = pcs[:n]
+			break
+		}
+		pcs = make([]uintptr, 2*len(pcs))
+	}
+
+	trim := atomic.LoadInt32(&trimInternalFrames) == 1
+	frames := make([]Frame, 0, len(pcs))
+	iter := runtime.CallersFrames(pcs)
+	for {
+		f, more := iter.Next()
+		if !trim || !isInternalFrame(f.Function) {
+			frames = append(frames, Frame{
+				PC:       f.PC,
+				Function: f.Function,
+				File:     f.File,
+				Line:     f.Line,
+				// The runtime has no Func for a call inlined into its caller
+				Inlined: f.Func == nil && f.Function != "",
+			})
+		}
+		if !more {
+			return frames
+		}
+	}
+}
+
+// GoWithRecoverStack starts a goroutine like GoWithRecover but hands customRecover the panic
+// stack as resolved frames from CaptureStack instead of leaving it to capture a string stack
+// A nil customRecover logs the panic with the configured logger
+func GoWithRecoverStack(goroutine func(), customRecover func(err any, stack []Frame)) {
+	if customRecover == nil {
+		GoWithRecover(goroutine, nil)
+		return
+	}
+	GoWithRecover(goroutine, func(err any) {
+		customRecover(err, captureStack(3)) // runtime.Callers, captureStack and this closure
+	})
+}
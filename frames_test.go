@@ -0,0 +1,100 @@
+package tsafe
+
+import (
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tinystack/tsafe/internal/userpanic"
+)
+
+// inlinedCapture is small enough for the compiler to inline into its caller
+func inlinedCapture() []Frame {
+	return CaptureStack()
+}
+
+// notInlinedCapture is the control case for inlinedCapture
+//
+//go:noinline
+func notInlinedCapture() []Frame {
+	return CaptureStack()
+}
+
+// bodyLine returns the line of the single statement of fn, right after its declaration
+func bodyLine(fn any) int {
+	f := runtime.FuncForPC(reflect.ValueOf(fn).Pointer())
+	_, line := f.FileLine(f.Entry())
+	return line + 1
+}
+
+func TestCaptureStack(t *testing.T) {
+	t.Run("should start at the caller", func(t *testing.T) {
+		frames := CaptureStack()
+		if assert.NotEmpty(t, frames) {
+			assert.True(t, strings.HasSuffix(frames[0].Function, "TestCaptureStack.func1"))
+			assert.True(t, strings.HasSuffix(frames[0].File, "frames_test.go"))
+			assert.NotZero(t, frames[0].Line)
+			assert.NotZero(t, frames[0].PC)
+		}
+	})
+
+	// Relies on the default compiler optimizations; -gcflags=-l disables inlining
+	t.Run("should report inlined calls as their own frames", func(t *testing.T) {
+		frames := inlinedCapture()
+		if assert.Greater(t, len(frames), 1) {
+			assert.True(t, strings.HasSuffix(frames[0].Function, ".inlinedCapture"), frames[0].Function)
+			assert.Equal(t, bodyLine(inlinedCapture), frames[0].Line)
+			assert.True(t, frames[0].Inlined)
+			assert.True(t, strings.HasSuffix(frames[1].Function, "TestCaptureStack.func2"), frames[1].Function)
+			assert.False(t, frames[1].Inlined)
+		}
+	})
+
+	t.Run("should not mark calls that were not inlined", func(t *testing.T) {
+		frames := notInlinedCapture()
+		if assert.NotEmpty(t, frames) {
+			assert.True(t, strings.HasSuffix(frames[0].Function, ".notInlinedCapture"), frames[0].Function)
+			assert.Equal(t, bodyLine(notInlinedCapture), frames[0].Line)
+			assert.False(t, frames[0].Inlined)
+		}
+	})
+}
+
+func TestGoWithRecoverStack(t *testing.T) {
+	t.Run("should pass frames including the panic site", func(t *testing.T) {
+		SetTrimInternalFrames(true)
+		defer SetTrimInternalFrames(false)
+
+		result := make(chan []Frame, 1)
+		GoWithRecoverStack(func() {
+			userpanic.Panic("frames panic")
+		}, func(err any, stack []Frame) {
+			assert.Equal(t, "frames panic", err)
+			result <- stack
+		})
+
+		select {
+		case frames := <-result:
+			if assert.NotEmpty(t, frames) {
+				assert.True(t, strings.HasSuffix(frames[0].Function, "userpanic.Panic"), frames[0].Function)
+			}
+			for _, f := range frames {
+				assert.NotEqual(t, "runtime.gopanic", f.Function)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("recover function was not called")
+		}
+	})
+
+	t.Run("should log with a nil recover function", func(t *testing.T) {
+		logger := &mockLogger{}
+		SetLogger(logger)
+		defer SetLogger(&defaultLoggerImpl{})
+
+		GoWithRecoverStack(func() { panic("logged") }, nil)
+		assert.Eventually(t, func() bool { return logger.getCallCount() == 1 }, time.Second, time.Millisecond)
+	})
+}
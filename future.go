@@ -0,0 +1,45 @@
+package tsafe
+
+// Future2 holds the two results of a function started with GoValue2 once it finished
+type Future2[A, B any] struct {
+	done chan struct{}
+	a    A
+	b    B
+	err  error
+}
+
+// GoValue2 starts fn in a recovered goroutine and returns a future for its two results,
+// so two-value functions can run asynchronously without boxing their results in a struct
+// A panic in fn is not logged: it is returned by Get as a *PanicError
+func GoValue2[A, B any](fn func() (A, B)) *Future2[A, B] {
+	f := &Future2[A, B]{done: make(chan struct{})}
+	if fn == nil {
+		close(f.done)
+		return f
+	}
+
+	spawn(func() {
+		defer close(f.done)
+		var a A
+		var b B
+		if pe := runRecovered(func() { a, b = fn() }); pe != nil {
+			f.err = pe
+			return
+		}
+		f.a, f.b = a, b
+	})
+	return f
+}
+
+// Get blocks until the function finished and returns its results
+// If it panicked, both values are zero and the error is a *PanicError
+// Get can be called any number of times, from any goroutine
+func (f *Future2[A, B]) Get() (A, B, error) {
+	<-f.done
+	return f.a, f.b, f.err
+}
+
+// Done returns a channel closed once the function finished
+func (f *Future2[A, B]) Done() <-chan struct{} {
+	return f.done
+}
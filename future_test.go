@@ -0,0 +1,45 @@
+package tsafe
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoValue2(t *testing.T) {
+	t.Run("should return both results", func(t *testing.T) {
+		f := GoValue2(func() (int, error) { return strconv.Atoi("42") })
+		n, parseErr, err := f.Get()
+		assert.NoError(t, err)
+		assert.NoError(t, parseErr)
+		assert.Equal(t, 42, n)
+
+		<-f.Done()
+		n, _, _ = f.Get()
+		assert.Equal(t, 42, n)
+	})
+
+	t.Run("should return zero values and a PanicError on panic", func(t *testing.T) {
+		mock := &mockLogger{}
+		SetLogger(mock)
+		defer SetLogger(&defaultLoggerImpl{})
+
+		a, b, err := GoValue2(func() (string, int) { panic("future panic") }).Get()
+		assert.Zero(t, a)
+		assert.Zero(t, b)
+		var pe *PanicError
+		if assert.True(t, errors.As(err, &pe)) {
+			assert.Equal(t, "future panic", pe.Value)
+		}
+		assert.Equal(t, 0, mock.getCallCount())
+	})
+
+	t.Run("should complete immediately for a nil function", func(t *testing.T) {
+		a, b, err := GoValue2[int, string](nil).Get()
+		assert.Zero(t, a)
+		assert.Zero(t, b)
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,33 @@
+package tsafe
+
+import "runtime/debug"
+
+// GoErr starts a goroutine running an error-returning function with panic recovery
+// onError is called once if fn returns a non-nil error or panics, in which case it receives
+// a *PanicError; it is not called when fn returns nil. This unifies error and panic
+// handling into a single callback. A panic in onError is logged with the configured logger
+// and onError is not called again. With a nil onError, returned errors are ignored and
+// panics are logged with the configured logger
+func GoErr(fn func() error, onError func(error)) {
+	if fn == nil {
+		return // Avoid creating goroutine for nil function
+	}
+	if onError == nil {
+		GoWithRecover(func() { _ = fn() }, nil)
+		return
+	}
+
+	// A panicking onError is logged rather than fed back into onError, so it still runs once
+	callOnError := func(err error) {
+		if pe := runRecovered(func() { onError(err) }); pe != nil {
+			reportPanic(pe.Value, pe.Stack, nil)
+		}
+	}
+	GoWithRecover(func() {
+		if err := fn(); err != nil {
+			callOnError(err)
+		}
+	}, func(err any) {
+		callOnError(newPanicError(err, debug.Stack()))
+	})
+}
@@ -0,0 +1,90 @@
+package tsafe
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoErr(t *testing.T) {
+	t.Run("should pass returned errors to the callback", func(t *testing.T) {
+		expected := errors.New("failed")
+		errs := make(chan error, 1)
+		GoErr(func() error {
+			return expected
+		}, func(err error) {
+			errs <- err
+		})
+
+		select {
+		case err := <-errs:
+			assert.Equal(t, expected, err)
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("onError was not called")
+		}
+	})
+
+	t.Run("should pass panics as PanicError", func(t *testing.T) {
+		errs := make(chan error, 1)
+		GoErr(func() error {
+			panic("goerr panic")
+		}, func(err error) {
+			errs <- err
+		})
+
+		select {
+		case err := <-errs:
+			var pe *PanicError
+			if assert.True(t, errors.As(err, &pe)) {
+				assert.Equal(t, "goerr panic", pe.Value)
+				assert.NotEmpty(t, pe.Stack)
+			}
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("onError was not called")
+		}
+	})
+
+	t.Run("should not call the callback on success", func(t *testing.T) {
+		errs := make(chan error, 1)
+		done := make(chan struct{})
+		GoErr(func() error {
+			defer close(done)
+			return nil
+		}, func(err error) {
+			errs <- err
+		})
+		<-done
+		time.Sleep(10 * time.Millisecond)
+		assert.Len(t, errs, 0)
+	})
+
+	t.Run("should call a panicking callback only once", func(t *testing.T) {
+		mock := &mockLogger{}
+		SetLogger(mock)
+		defer SetLogger(&defaultLoggerImpl{})
+
+		var calls int32
+		GoErr(func() error {
+			return errors.New("failed")
+		}, func(err error) {
+			atomic.AddInt32(&calls, 1)
+			panic("callback panic")
+		})
+
+		assert.Eventually(t, func() bool { return mock.getCallCount() == 1 }, time.Second, time.Millisecond)
+		assert.Equal(t, "callback panic", mock.getLastError())
+		waitForNoActiveGoroutines(t)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("should handle nil callbacks", func(t *testing.T) {
+		assert.NotPanics(t, func() {
+			GoErr(nil, nil)
+			GoErr(func() error { panic("ignored") }, nil)
+		})
+		time.Sleep(10 * time.Millisecond)
+	})
+}
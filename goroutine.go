@@ -1,10 +1,17 @@
 // Package tsafe provides utilities for safe goroutine execution with panic recovery
+//
+// The module supports Go 1.18 and newer. Scope, NewScope and WithScope are only built with
+// Go 1.20 or newer, since they rely on context cancellation causes
 package tsafe
 
 import (
+	"bytes"
+	"context"
+	"io"
 	"log"
 	"runtime/debug"
 	"sync"
+	"sync/atomic"
 )
 
 // Logger defines the interface for custom error logging
@@ -14,13 +21,37 @@ type Logger interface {
 	Print(err, stack any)
 }
 
+// StreamingLogger is implemented by loggers that consume the stack trace as a stream
+// When the configured logger implements it, PrintStream is called instead of Print and
+// PrintLevel, so huge stacks can be copied to disk or the network without converting them
+// to strings or holding extra copies. The reader is only valid during the call
+type StreamingLogger interface {
+	// PrintStream logs an error and reads its stack trace from stack
+	PrintStream(err any, stack io.Reader)
+}
+
 // defaultLoggerImpl is the default implementation of Logger interface
 type defaultLoggerImpl struct{}
 
 // Print implements the Logger interface for defaultLoggerImpl
-// It logs errors using the standard log package
+// It logs errors using the standard log package, followed by the errors they wrap as "caused by:"
+// lines and, with SetIncludeMemStats, a memory summary line
 func (l *defaultLoggerImpl) Print(err, stack any) {
-	log.Printf("Error in goroutine: %s\nStack trace: %s\n", err, stack)
+	if getDefaultLoggerFormat() == FormatJSON {
+		printJSON(err, stack, nil)
+		return
+	}
+	log.Printf("Error in goroutine: %s%s%s\nStack trace: %s\n", err, formatCauses(causeChain(err)), formatMemStats(), stack)
+}
+
+// PrintFields implements the FieldLogger interface for defaultLoggerImpl
+// Fields are appended to the error line sorted by key
+func (l *defaultLoggerImpl) PrintFields(err, stack any, fields map[string]any) {
+	if getDefaultLoggerFormat() == FormatJSON {
+		printJSON(err, stack, fields)
+		return
+	}
+	log.Printf("Error in goroutine: %s %s%s%s\nStack trace: %s\n", err, formatFields(fields), formatCauses(causeChain(err)), formatMemStats(), stack)
 }
 
 // Thread-safe global logger management
@@ -37,6 +68,27 @@ func SetLogger(l Logger) {
 	defaultLogger = l
 }
 
+// SetDefaultLoggerIfUnset sets l as the logger only while the current logger is still the
+// built-in default, and reports whether it did, so libraries can provide a fallback without
+// overriding a logger chosen by the application
+// This function is thread-safe and atomic with respect to concurrent SetLogger calls
+func SetDefaultLoggerIfUnset(l Logger) bool {
+	loggerMutex.Lock()
+	defer loggerMutex.Unlock()
+	if _, isDefault := defaultLogger.(*defaultLoggerImpl); !isDefault {
+		return false
+	}
+	defaultLogger = l
+	return true
+}
+
+// CurrentLogger returns the logger currently used for goroutine error handling, e.g. to wrap
+// it or to restore it later with SetLogger
+// This function is thread-safe
+func CurrentLogger() Logger {
+	return getLogger()
+}
+
 // getLogger returns the current logger in a thread-safe manner
 func getLogger() Logger {
 	loggerMutex.RLock()
@@ -44,15 +96,103 @@ func getLogger() Logger {
 	return defaultLogger
 }
 
+// Thread-safe global log filter management
+var (
+	logFilter   func(err any) bool
+	filterMutex sync.RWMutex
+)
+
+// SetLogFilter sets a filter deciding which recovered panics are logged by Go
+// Returning false suppresses logging for that panic; it is still recovered
+// Passing nil removes the filter so every panic is logged
+// This function is thread-safe
+func SetLogFilter(filter func(err any) bool) {
+	filterMutex.Lock()
+	defer filterMutex.Unlock()
+	logFilter = filter
+}
+
+// getLogFilter returns the current log filter in a thread-safe manner
+func getLogFilter() func(err any) bool {
+	filterMutex.RLock()
+	defer filterMutex.RUnlock()
+	return logFilter
+}
+
 // Go starts a goroutine with automatic panic recovery
 // When a panic occurs, it will be logged using the configured logger
 // This is the most convenient way to start a safe goroutine
 func Go(goroutine func()) {
-	GoWithRecover(goroutine, func(err any) {
-		if logger := getLogger(); logger != nil {
-			logger.Print(err, debug.Stack())
+	GoWithRecover(goroutine, logPanic)
+}
+
+// logPanic reports a recovered panic through the configured logger
+// It must be called from the deferred recovery function so the stack still points at the panic site
+func logPanic(err any) {
+	reportPanic(err, debug.Stack(), nil)
+}
+
+// reportPanic passes a recovered panic and its already captured stack to the registered
+// sinks and the configured logger
+// Panics classified below the minimum level are dropped. The per-goroutine fields are merged
+// over the global fields; loggers implementing FieldLogger receive them, LeveledLogger
+// implementations receive the level, LeveledFieldLogger implementations both, and other
+// loggers only get the error and stack
+// A panicking logger is recovered and the event handed to the dead-letter sink instead
+func reportPanic(err any, stack []byte, fields map[string]any) {
+	reportPanicContext(nil, err, stack, fields)
+}
+
+// reportPanicContext is reportPanic with a context routed to loggers implementing ContextLogger
+// A nil ctx means no context is available
+func reportPanicContext(ctx context.Context, err any, stack []byte, fields map[string]any) {
+	if filter := getLogFilter(); filter != nil && !filter(err) {
+		return
+	}
+	level := classifyPanic(err)
+	if !levelEnabled(level) {
+		return
+	}
+
+	deliverEvent(ctx, newPanicEvent(err, stack, mergeFields(getGlobalFields(), fields), level))
+}
+
+// deliverEvent hands event to the registered sinks and the configured logger
+// A panicking logger is recovered and the event handed to the dead-letter sink instead
+func deliverEvent(ctx context.Context, event PanicEvent) {
+	emitToSinks(event)
+	if logger := getLogger(); logger != nil {
+		if callSafely(func() { logEvent(ctx, logger, event) }) != nil {
+			deadLetter(event)
 		}
-	})
+	}
+}
+
+// logEvent delivers a panic event to a logger through the richest interface it implements
+// ContextLogger is only used when a context is available
+func logEvent(ctx context.Context, logger Logger, event PanicEvent) {
+	err := truncateValue(formatValue(event.Value))
+	if cl, ok := logger.(ContextLogger); ok && ctx != nil {
+		cl.PrintContext(ctx, err, event.Stack, event.Fields)
+		return
+	}
+	if lfl, ok := logger.(LeveledFieldLogger); ok && len(event.Fields) > 0 {
+		lfl.PrintLevelFields(event.Level, err, event.Stack, event.Fields)
+		return
+	}
+	if fl, ok := logger.(FieldLogger); ok && len(event.Fields) > 0 {
+		fl.PrintFields(err, event.Stack, event.Fields)
+		return
+	}
+	if sl, ok := logger.(StreamingLogger); ok {
+		sl.PrintStream(err, bytes.NewReader(event.Stack))
+		return
+	}
+	if ll, ok := logger.(LeveledLogger); ok {
+		ll.PrintLevel(event.Level, err, event.Stack)
+		return
+	}
+	logger.Print(err, event.Stack)
 }
 
 // GoWithRecover starts a goroutine with custom panic recovery handling
@@ -61,17 +201,140 @@ func Go(goroutine func()) {
 //   - customRecover: the function to handle panic recovery (called if panic occurs)
 //
 // This provides more control over error handling compared to Go()
+// If customRecover is nil the panic is logged with the configured logger, like Go(),
+// rather than silently dropped; use GoSilent to discard panics on purpose
+// The caller blocks while the limit set by SetMaxGlobalGoroutines is reached
+// After the context set by SetShutdownContext ends, no goroutine is started, see SetShutdownBehavior
+// SetSpawnStrategy may make it run the function inline instead of in a new goroutine
 func GoWithRecover(goroutine func(), customRecover func(err any)) {
+	goWithRecover(goroutine, customRecover, nil)
+}
+
+// goWithRecover implements GoWithRecover; skipped, if not nil, is called once it is known that
+// goroutine never ran: it was dropped after shutdown, replaced by SetChaos, or a middleware did
+// not call next. It runs after customRecover, so helpers waiting on goroutine can clean up
+func goWithRecover(goroutine func(), customRecover func(err any), skipped func()) {
 	if goroutine == nil {
 		return // Avoid creating goroutine for nil function
 	}
+	if customRecover == nil {
+		customRecover = logPanic
+	}
+	shutdown, behavior := afterShutdown()
+	if shutdown {
+		warnSpawnAfterShutdown()
+		if behavior == ShutdownDrop {
+			if skipped != nil {
+				skipped()
+			}
+			return
+		}
+	}
+
+	checkSpawnBeforeStart()
+	runSpawnHooks()
+	if skipped != nil {
+		run := watchSkipped(goroutine, customRecover, skipped)
+		if shutdown || shouldRunInline() {
+			run()
+			return
+		}
+		spawnThrottled(run)
+		return
+	}
+	goroutine = applyMiddleware(injectChaos(goroutine))
+	if shutdown || shouldRunInline() {
+		runWithRecover(goroutine, customRecover)
+		return
+	}
+	spawnThrottled(func() { runWithRecover(goroutine, customRecover) })
+}
 
-	go func() {
+// watchSkipped wraps goroutine in chaos and the middlewares like goWithRecover does, and returns
+// a function running the result that calls skipped if goroutine itself was never called
+func watchSkipped(goroutine func(), customRecover func(err any), skipped func()) func() {
+	var ran int32
+	wrapped := applyMiddleware(injectChaos(func() {
+		atomic.StoreInt32(&ran, 1)
+		goroutine()
+	}))
+	return func() {
 		defer func() {
-			if err := recover(); err != nil && customRecover != nil {
-				customRecover(err)
+			if atomic.LoadInt32(&ran) == 0 {
+				skipped()
 			}
 		}()
+		runWithRecover(wrapped, customRecover)
+	}
+}
+
+// runWithRecover runs goroutine, counting a panic and handing it to customRecover
+// It is a plain function rather than a closure so the happy path allocates nothing
+func runWithRecover(goroutine func(), customRecover func(err any)) {
+	nilPanic := false
+	func() {
+		completed := false
+		defer func() {
+			if completed {
+				return
+			}
+			err := recover()
+			if err == nil {
+				// panic(nil) under GODEBUG=panicnil=1, or runtime.Goexit
+				nilPanic = true
+				return
+			}
+			countPanic(debug.Stack())
+			customRecover(normalizePanicValue(err))
+		}()
 		goroutine()
+		completed = true
 	}()
+	// runtime.Goexit never returns here, so this was a recovered panic(nil)
+	if nilPanic {
+		countPanic(nil)
+		customRecover(normalizePanicValue(nil))
+	}
+}
+
+// GoWithRecoverAndLog starts a goroutine whose panics are both logged with the configured
+// logger, like Go, and handed to customRecover, like GoWithRecover
+// The panic is logged first and customRecover is called afterwards. A nil customRecover behaves like Go
+func GoWithRecoverAndLog(goroutine func(), customRecover func(err any)) {
+	if customRecover == nil {
+		Go(goroutine)
+		return
+	}
+	GoWithRecover(goroutine, func(err any) {
+		logPanic(err)
+		customRecover(err)
+	})
+}
+
+// GoSilent starts a goroutine whose panics are recovered and intentionally discarded
+// Nothing is logged; prefer Go unless dropping failures is really what you want
+func GoSilent(goroutine func()) {
+	GoWithRecover(goroutine, func(err any) {})
+}
+
+// spawn starts fn in a new goroutine tracked by the package statistics
+// Every goroutine started by tsafe to run user code goes through spawn
+func spawn(fn func()) {
+	trackSpawn()
+	schedule(fn)
+}
+
+// trackSpawn records a goroutine about to be scheduled in the package statistics
+func trackSpawn() {
+	atomic.AddUint64(&statsSpawned, 1)
+	atomic.AddInt64(&statsActive, 1)
+}
+
+// schedule hands fn to the configured scheduler; the goroutine must already be tracked
+func schedule(fn func()) {
+	getScheduler()(func() {
+		defer releaseGlobalSlot()
+		defer recordDuration(timingStart())
+		fn()
+	})
 }
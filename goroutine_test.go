@@ -1,7 +1,9 @@
 package tsafe
 
 import (
+	"io"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -128,6 +130,77 @@ func TestGoWithRecover(t *testing.T) {
 		})
 		time.Sleep(10 * time.Millisecond)
 	})
+
+	t.Run("should log panics when recover function is nil", func(t *testing.T) {
+		mock := &mockLogger{}
+		originalLogger := getLogger()
+		SetLogger(mock)
+		defer SetLogger(originalLogger)
+
+		GoWithRecover(func() {
+			panic("nil recover panic")
+		}, nil)
+
+		assert.Eventually(t, func() bool {
+			return mock.getCallCount() == 1
+		}, 100*time.Millisecond, time.Millisecond)
+		assert.Equal(t, "nil recover panic", mock.getLastError())
+	})
+}
+
+func TestGoWithRecoverAndLog(t *testing.T) {
+	t.Run("should log before calling the recover function", func(t *testing.T) {
+		mock := &mockLogger{}
+		originalLogger := getLogger()
+		SetLogger(mock)
+		defer SetLogger(originalLogger)
+
+		logged := make(chan int, 1)
+		GoWithRecoverAndLog(func() {
+			panic("logged and recovered")
+		}, func(err any) {
+			assert.Equal(t, "logged and recovered", err)
+			logged <- mock.getCallCount()
+		})
+
+		select {
+		case calls := <-logged:
+			assert.Equal(t, 1, calls)
+			assert.Equal(t, "logged and recovered", mock.getLastError())
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("recover function was not called")
+		}
+	})
+
+	t.Run("should behave like Go with a nil recover function", func(t *testing.T) {
+		mock := &mockLogger{}
+		originalLogger := getLogger()
+		SetLogger(mock)
+		defer SetLogger(originalLogger)
+
+		GoWithRecoverAndLog(func() { panic("only logged") }, nil)
+		assert.Eventually(t, func() bool {
+			return mock.getCallCount() == 1
+		}, 100*time.Millisecond, time.Millisecond)
+	})
+}
+
+func TestGoSilent(t *testing.T) {
+	t.Run("should discard panics without logging", func(t *testing.T) {
+		mock := &mockLogger{}
+		originalLogger := getLogger()
+		SetLogger(mock)
+		defer SetLogger(originalLogger)
+
+		done := make(chan struct{})
+		GoSilent(func() {
+			defer close(done)
+			panic("silent panic")
+		})
+		<-done
+		time.Sleep(10 * time.Millisecond)
+		assert.Equal(t, 0, mock.getCallCount())
+	})
 }
 
 func TestSetLogger(t *testing.T) {
@@ -156,6 +229,98 @@ func TestSetLogger(t *testing.T) {
 	})
 }
 
+func TestSetDefaultLoggerIfUnset(t *testing.T) {
+	defer SetLogger(&defaultLoggerImpl{})
+
+	t.Run("should replace the built-in default", func(t *testing.T) {
+		SetLogger(&defaultLoggerImpl{})
+		fallback := &mockLogger{}
+		assert.True(t, SetDefaultLoggerIfUnset(fallback))
+		assert.Same(t, fallback, getLogger())
+	})
+
+	t.Run("should keep a logger chosen by the application", func(t *testing.T) {
+		app := &mockLogger{}
+		SetLogger(app)
+		assert.False(t, SetDefaultLoggerIfUnset(&mockLogger{}))
+		assert.Same(t, app, getLogger())
+	})
+
+	t.Run("should treat a nil logger as a choice", func(t *testing.T) {
+		SetLogger(nil)
+		assert.False(t, SetDefaultLoggerIfUnset(&mockLogger{}))
+		assert.Nil(t, getLogger())
+	})
+
+	t.Run("should let only one concurrent caller win", func(t *testing.T) {
+		SetLogger(&defaultLoggerImpl{})
+		var wins int32
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if SetDefaultLoggerIfUnset(&mockLogger{}) {
+					atomic.AddInt32(&wins, 1)
+				}
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, int32(1), atomic.LoadInt32(&wins))
+	})
+}
+
+func TestSetLogFilter(t *testing.T) {
+	t.Run("should suppress filtered panics", func(t *testing.T) {
+		mock := &mockLogger{}
+		originalLogger := getLogger()
+		SetLogger(mock)
+		defer SetLogger(originalLogger)
+
+		sentinel := "expected control flow"
+		filtered := make(chan any, 2)
+		SetLogFilter(func(err any) bool {
+			filtered <- err
+			return err != sentinel
+		})
+		defer SetLogFilter(nil)
+
+		Go(func() {
+			panic(sentinel)
+		})
+		select {
+		case err := <-filtered:
+			assert.Equal(t, sentinel, err)
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("filter was not called")
+		}
+		assert.Equal(t, 0, mock.getCallCount())
+
+		Go(func() {
+			panic("unexpected")
+		})
+		assert.Eventually(t, func() bool {
+			return mock.getCallCount() == 1
+		}, 100*time.Millisecond, time.Millisecond)
+		assert.Equal(t, "unexpected", mock.getLastError())
+	})
+
+	t.Run("should log everything when filter is nil", func(t *testing.T) {
+		mock := &mockLogger{}
+		originalLogger := getLogger()
+		SetLogger(mock)
+		defer SetLogger(originalLogger)
+		SetLogFilter(nil)
+
+		Go(func() {
+			panic("test panic")
+		})
+		assert.Eventually(t, func() bool {
+			return mock.getCallCount() == 1
+		}, 100*time.Millisecond, time.Millisecond)
+	})
+}
+
 // Benchmark tests
 func BenchmarkGo(b *testing.B) {
 	b.ResetTimer()
@@ -176,3 +341,55 @@ func BenchmarkGoWithRecover(b *testing.B) {
 		})
 	}
 }
+
+// mockStreamingLogger reads the streamed stack of every panic
+type mockStreamingLogger struct {
+	mockLogger
+	streamMutex sync.Mutex
+	streamed    []byte
+}
+
+func (m *mockStreamingLogger) PrintStream(err any, stack io.Reader) {
+	data, _ := io.ReadAll(stack)
+	m.streamMutex.Lock()
+	m.streamed = data
+	m.streamMutex.Unlock()
+	m.mockLogger.Print(err, nil)
+}
+
+func (m *mockStreamingLogger) getLastStack() []byte {
+	m.streamMutex.Lock()
+	defer m.streamMutex.Unlock()
+	return m.streamed
+}
+
+func TestStreamingLogger(t *testing.T) {
+	t.Run("should stream the stack to a StreamingLogger", func(t *testing.T) {
+		mock := &mockStreamingLogger{}
+		originalLogger := getLogger()
+		SetLogger(mock)
+		defer SetLogger(originalLogger)
+
+		Go(func() { panic("streamed panic") })
+
+		assert.Eventually(t, func() bool {
+			return mock.getCallCount() == 1
+		}, 100*time.Millisecond, time.Millisecond)
+		assert.Equal(t, "streamed panic", mock.getLastError())
+		assert.Contains(t, string(mock.getLastStack()), "goroutine ")
+	})
+}
+
+func TestCurrentLogger(t *testing.T) {
+	t.Run("should return the logger set with SetLogger", func(t *testing.T) {
+		original := CurrentLogger()
+		defer SetLogger(original)
+
+		mock := &mockLogger{}
+		SetLogger(mock)
+		assert.Same(t, mock, CurrentLogger())
+
+		SetLogger(original)
+		assert.Equal(t, original, CurrentLogger())
+	})
+}
@@ -0,0 +1,191 @@
+package tsafe
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Group runs a collection of tasks in recovered goroutines and waits for them to finish
+// Panics are converted to *PanicError so Wait returns them like any other error
+// The zero value is ready to use
+type Group struct {
+	wg       sync.WaitGroup
+	errOnce  sync.Once
+	err      error
+	mu       sync.Mutex
+	failures chan *PanicError
+	done     bool
+	dedup    *panicDedup // nil unless DedupPanics was called
+}
+
+// Go starts fn in a new goroutine that belongs to the group
+// The first non-nil error or recovered panic is returned by Wait
+func (g *Group) Go(fn func() error) {
+	if fn == nil {
+		return // Avoid creating goroutine for nil function
+	}
+
+	g.wg.Add(1)
+	spawn(func() {
+		defer g.wg.Done()
+		if err := g.run(fn); err != nil {
+			g.errOnce.Do(func() {
+				g.err = err
+			})
+		}
+	})
+}
+
+// run executes fn and converts a panic into a *PanicError
+func (g *Group) run(fn func() error) (err error) {
+	if pe := runRecovered(func() { err = fn() }); pe != nil {
+		g.publish(pe)
+		if g.dedup != nil {
+			g.dedup.add(pe)
+		}
+		return pe
+	}
+	return err
+}
+
+// publish forwards a panic to the failures subscriber, if any
+// Panics of tasks started after Wait completed are not published since the channel is closed;
+// they are still recorded as the group's error like any other panic
+// The lock is held during the send so Wait cannot close the channel underneath it
+func (g *Group) publish(pe *PanicError) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.failures != nil && !g.done {
+		g.failures <- pe
+	}
+}
+
+// Failures returns a channel that streams panics from the group's tasks as they happen
+// Only panics recovered after subscribing are delivered, and the channel is closed once Wait completes
+// Subscribers must keep receiving until the channel is closed, otherwise failing tasks block
+func (g *Group) Failures() <-chan *PanicError {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.failures == nil {
+		g.failures = make(chan *PanicError)
+		if g.done {
+			close(g.failures)
+		}
+	}
+	return g.failures
+}
+
+// Wait blocks until all tasks have finished and returns the first error or panic
+func (g *Group) Wait() error {
+	g.wg.Wait()
+
+	g.mu.Lock()
+	if !g.done {
+		g.done = true
+		if g.failures != nil {
+			close(g.failures)
+		}
+	}
+	g.mu.Unlock()
+	if g.dedup != nil {
+		g.dedup.flush()
+	}
+	return g.err
+}
+
+// DedupPanics makes the group also log its tasks' panics with the configured logger, once per
+// distinct failure: panics with the same value and stack within window of the first one are
+// aggregated into a single report carrying a "count" field with the number of occurrences
+// Aggregated reports are logged when Wait completes, or earlier when an identical panic
+// arrives after its window ended. Call it before starting tasks
+func (g *Group) DedupPanics(window time.Duration) {
+	g.dedup = &panicDedup{window: window, entries: make(map[string]*dedupEntry)}
+}
+
+// dedupEntry aggregates identical panics
+type dedupEntry struct {
+	pe    *PanicError
+	count int
+	first time.Time
+}
+
+// panicDedup aggregates identical panics of a group until they are flushed
+type panicDedup struct {
+	window  time.Duration
+	mu      sync.Mutex
+	entries map[string]*dedupEntry
+	order   []string // keys in order of first occurrence
+}
+
+// add records a panic, logging the previous aggregate of the same failure if its window ended
+func (d *panicDedup) add(pe *PanicError) {
+	key := fmt.Sprintf("%v\n%s", pe.Value, stackKey(pe.Stack))
+	now := time.Now()
+
+	d.mu.Lock()
+	entry, ok := d.entries[key]
+	if ok && now.Sub(entry.first) < d.window {
+		entry.count++
+		d.mu.Unlock()
+		return
+	}
+	d.entries[key] = &dedupEntry{pe: pe, count: 1, first: now}
+	if !ok {
+		d.order = append(d.order, key)
+	}
+	d.mu.Unlock()
+
+	if ok {
+		entry.report()
+	}
+}
+
+// flush logs every pending aggregate in order of first occurrence
+func (d *panicDedup) flush() {
+	d.mu.Lock()
+	entries := make([]*dedupEntry, 0, len(d.order))
+	for _, key := range d.order {
+		entries = append(entries, d.entries[key])
+	}
+	d.entries = make(map[string]*dedupEntry)
+	d.order = nil
+	d.mu.Unlock()
+
+	for _, e := range entries {
+		e.report()
+	}
+}
+
+// report logs an aggregate, with the count field if it covers several panics
+func (e *dedupEntry) report() {
+	var fields map[string]any
+	if e.count > 1 {
+		fields = map[string]any{groupCountField: e.count}
+	}
+	reportPanic(e.pe.Value, e.pe.Stack, fields)
+}
+
+// stackKey reduces a stack to what identifies the failure site: the goroutine header and the
+// argument values of each frame, which differ between otherwise identical panics, are dropped
+func stackKey(stack []byte) []byte {
+	lines := bytes.Split(stack, []byte("\n"))
+	key := make([][]byte, 0, len(lines))
+	for _, line := range lines {
+		switch {
+		case bytes.HasPrefix(line, []byte("goroutine ")):
+			continue
+		case bytes.HasPrefix(line, []byte("created by ")):
+			if i := bytes.Index(line, []byte(" in goroutine ")); i > 0 {
+				line = line[:i]
+			}
+		case len(line) > 0 && line[0] != '\t':
+			if i := bytes.LastIndexByte(line, '('); i > 0 {
+				line = line[:i]
+			}
+		}
+		key = append(key, line)
+	}
+	return bytes.Join(key, []byte("\n"))
+}
@@ -0,0 +1,147 @@
+package tsafe
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroup(t *testing.T) {
+	t.Run("should return nil when all tasks succeed", func(t *testing.T) {
+		var g Group
+		for i := 0; i < 5; i++ {
+			g.Go(func() error { return nil })
+		}
+		assert.NoError(t, g.Wait())
+	})
+
+	t.Run("should return the first error", func(t *testing.T) {
+		var g Group
+		expected := errors.New("task failed")
+		g.Go(func() error { return expected })
+		assert.Equal(t, expected, g.Wait())
+	})
+
+	t.Run("should convert panics into PanicError", func(t *testing.T) {
+		var g Group
+		g.Go(func() error { panic("group panic") })
+
+		err := g.Wait()
+		var pe *PanicError
+		if assert.True(t, errors.As(err, &pe)) {
+			assert.Equal(t, "group panic", pe.Value)
+			assert.NotEmpty(t, pe.Stack)
+		}
+	})
+
+	t.Run("should stream failures and close after Wait", func(t *testing.T) {
+		var g Group
+		failures := g.Failures()
+
+		release := make(chan struct{})
+		for i := 0; i < 3; i++ {
+			g.Go(func() error {
+				<-release
+				panic("streamed panic")
+			})
+		}
+		g.Go(func() error { return nil })
+
+		received := make(chan int)
+		go func() {
+			count := 0
+			for pe := range failures {
+				assert.Equal(t, "streamed panic", pe.Value)
+				count++
+			}
+			received <- count
+		}()
+
+		close(release)
+		assert.Error(t, g.Wait())
+
+		select {
+		case count := <-received:
+			assert.Equal(t, 3, count)
+		case <-time.After(time.Second):
+			t.Fatal("failures channel was not closed")
+		}
+	})
+
+	t.Run("should return a closed channel after Wait", func(t *testing.T) {
+		var g Group
+		assert.NoError(t, g.Wait())
+
+		_, ok := <-g.Failures()
+		assert.False(t, ok)
+	})
+
+	t.Run("should not publish panics of tasks started after Wait", func(t *testing.T) {
+		var g Group
+		failures := g.Failures()
+		assert.NoError(t, g.Wait())
+
+		g.Go(func() error { panic("late panic") })
+		var pe *PanicError
+		if assert.ErrorAs(t, g.Wait(), &pe) {
+			assert.Equal(t, "late panic", pe.Value)
+		}
+		_, ok := <-failures
+		assert.False(t, ok)
+	})
+
+	t.Run("should handle nil function gracefully", func(t *testing.T) {
+		var g Group
+		assert.NotPanics(t, func() {
+			g.Go(nil)
+		})
+		assert.NoError(t, g.Wait())
+	})
+}
+
+func TestGroupDedupPanics(t *testing.T) {
+	t.Run("should log identical panics once with a count", func(t *testing.T) {
+		mock := &mockFieldLogger{}
+		SetLogger(mock)
+		defer SetLogger(&defaultLoggerImpl{})
+
+		var g Group
+		g.DedupPanics(time.Minute)
+		fail := func() error { panic("fan-out panic") }
+		for i := 0; i < 5; i++ {
+			g.Go(fail)
+		}
+		assert.Error(t, g.Wait())
+
+		calls, fields := mock.getFieldCalls()
+		assert.Equal(t, 1, calls)
+		assert.Equal(t, map[string]any{"count": 5}, fields)
+		assert.Equal(t, 0, mock.getCallCount())
+	})
+
+	t.Run("should log distinct panics separately", func(t *testing.T) {
+		mock := &mockLogger{}
+		SetLogger(mock)
+		defer SetLogger(&defaultLoggerImpl{})
+
+		var g Group
+		g.DedupPanics(time.Minute)
+		g.Go(func() error { panic("first") })
+		g.Go(func() error { panic("second") })
+		assert.Error(t, g.Wait())
+		assert.Equal(t, 2, mock.getCallCount())
+	})
+
+	t.Run("should not log without dedup", func(t *testing.T) {
+		mock := &mockLogger{}
+		SetLogger(mock)
+		defer SetLogger(&defaultLoggerImpl{})
+
+		var g Group
+		g.Go(func() error { panic("returned only") })
+		assert.Error(t, g.Wait())
+		assert.Equal(t, 0, mock.getCallCount())
+	})
+}
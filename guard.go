@@ -0,0 +1,49 @@
+package tsafe
+
+import (
+	"context"
+	"runtime/debug"
+	"time"
+)
+
+// crashFlushTimeout bounds how long GuardMain, GoCritical and GoWithRethrowIf wait for loggers
+// and sinks to flush before the process goes down
+const crashFlushTimeout = 5 * time.Second
+
+// GuardMain runs fn, typically the body of main, so a panic escaping it still leaves telemetry
+// behind: the panic is reported to the sinks and the configured logger, FlushAll waits up to
+// five seconds for buffered entries to be written, and the panic is re-raised so the process
+// still crashes with the usual exit status and runtime output
+//
+//	func main() {
+//		tsafe.GuardMain(run)
+//	}
+func GuardMain(fn func()) {
+	if fn == nil {
+		return
+	}
+	completed := false
+	defer func() {
+		if completed {
+			return
+		}
+		err := recover()
+		if err == nil {
+			return // runtime.Goexit, which must be left to unwind
+		}
+		stack := debug.Stack()
+		countPanic(stack)
+		reportPanic(normalizePanicValue(err), stack, nil)
+		flushBeforeCrash()
+		panic(err)
+	}()
+	fn()
+	completed = true
+}
+
+// flushBeforeCrash runs FlushAll, giving up after crashFlushTimeout
+func flushBeforeCrash() {
+	ctx, cancel := context.WithTimeout(context.Background(), crashFlushTimeout)
+	defer cancel()
+	_ = FlushAll(ctx)
+}
@@ -0,0 +1,37 @@
+package tsafe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGuardMain(t *testing.T) {
+	t.Run("should log, flush and re-panic", func(t *testing.T) {
+		inner := &mockLogger{}
+		async := NewAsyncLogger(inner, 10)
+		defer async.Close()
+		SetLogger(async)
+		defer SetLogger(&defaultLoggerImpl{})
+
+		assert.PanicsWithValue(t, "fatal", func() {
+			GuardMain(func() { panic("fatal") })
+		})
+		// The async logger was flushed before the panic was re-raised
+		assert.Equal(t, 1, inner.getCallCount())
+		assert.Equal(t, "fatal", inner.getLastError())
+	})
+
+	t.Run("should return normally when fn does not panic", func(t *testing.T) {
+		mock := &mockLogger{}
+		SetLogger(mock)
+		defer SetLogger(&defaultLoggerImpl{})
+
+		ran := false
+		assert.NotPanics(t, func() {
+			GuardMain(func() { ran = true })
+		})
+		assert.True(t, ran)
+		assert.Equal(t, 0, mock.getCallCount())
+	})
+}
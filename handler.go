@@ -0,0 +1,106 @@
+package tsafe
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"runtime/debug"
+)
+
+// Fields attached to panics recovered by SafeHandler
+const (
+	methodField = "method"
+	pathField   = "path"
+)
+
+// SafeHandler wraps h so a panic while handling a request is recovered instead of reaching
+// the server, whose own recovery only logs it and drops the connection
+// The panic is reported with the request's context, so loggers implementing ContextLogger
+// receive it, and with the fields set on the context by WithFields plus the request method
+// and path as "method" and "path" fields; then a 500 Internal Server Error is written if
+// nothing was written yet. Handlers can still hijack the connection or push resources when
+// the server's writer supports it
+// http.ErrAbortHandler is re-raised, since the server uses it to abort a response on purpose
+func SafeHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tw := &trackingResponseWriter{ResponseWriter: w}
+		defer func() {
+			err := recover()
+			if err == nil {
+				return
+			}
+			if err == http.ErrAbortHandler {
+				panic(err)
+			}
+			stack := debug.Stack()
+			countPanic(stack)
+			fields := mergeFields(contextFields(r.Context()), map[string]any{
+				methodField: r.Method,
+				pathField:   r.URL.Path,
+			})
+			reportPanicContext(r.Context(), normalizePanicValue(err), stack, fields)
+			if !tw.wroteHeader {
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			}
+		}()
+		h.ServeHTTP(tw, r)
+	})
+}
+
+// SafeHandlerFunc is SafeHandler for handler functions
+func SafeHandlerFunc(f http.HandlerFunc) http.HandlerFunc {
+	return SafeHandler(f).ServeHTTP
+}
+
+// trackingResponseWriter records whether the response header was written
+type trackingResponseWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+// WriteHeader implements http.ResponseWriter
+func (w *trackingResponseWriter) WriteHeader(code int) {
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Write implements http.ResponseWriter
+func (w *trackingResponseWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush implements http.Flusher when the underlying writer does
+func (w *trackingResponseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		w.wroteHeader = true
+		f.Flush()
+	}
+}
+
+// Hijack implements http.Hijacker when the underlying writer does
+// A panic after a successful hijack gets no 500 response, the connection now belongs to the handler
+func (w *trackingResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, http.ErrNotSupported
+	}
+	conn, rw, err := h.Hijack()
+	if err == nil {
+		w.wroteHeader = true
+	}
+	return conn, rw, err
+}
+
+// Push implements http.Pusher when the underlying writer does
+func (w *trackingResponseWriter) Push(target string, opts *http.PushOptions) error {
+	if p, ok := w.ResponseWriter.(http.Pusher); ok {
+		return p.Push(target, opts)
+	}
+	return http.ErrNotSupported
+}
+
+// Unwrap returns the underlying writer, for http.ResponseController
+func (w *trackingResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
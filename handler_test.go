@@ -0,0 +1,105 @@
+package tsafe
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSafeHandler(t *testing.T) {
+	t.Run("should recover panics and respond with 500", func(t *testing.T) {
+		mock := &mockContextLogger{}
+		SetLogger(mock)
+		defer SetLogger(&defaultLoggerImpl{})
+
+		h := SafeHandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic("handler panic")
+		})
+		req := httptest.NewRequest(http.MethodPost, "/orders/42", nil)
+		req = req.WithContext(context.WithValue(req.Context(), traceKey{}, "trace-1"))
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Equal(t, "handler panic", mock.getLastError())
+		assert.Equal(t, "trace-1", mock.getLastContext().Value(traceKey{}))
+		assert.Equal(t, map[string]any{"method": "POST", "path": "/orders/42"}, mock.getLastFields())
+	})
+
+	t.Run("should merge context fields into the report", func(t *testing.T) {
+		mock := &mockContextLogger{}
+		SetLogger(mock)
+		defer SetLogger(&defaultLoggerImpl{})
+
+		h := SafeHandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic("handler panic")
+		})
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req = req.WithContext(WithFields(req.Context(), map[string]any{"user": "u1"}))
+		h.ServeHTTP(httptest.NewRecorder(), req)
+
+		assert.Equal(t, map[string]any{"user": "u1", "method": "GET", "path": "/"}, mock.getLastFields())
+	})
+
+	t.Run("should let handlers hijack the connection", func(t *testing.T) {
+		SetLogger(&mockLogger{})
+		defer SetLogger(&defaultLoggerImpl{})
+
+		hijacked := make(chan error, 1)
+		srv := httptest.NewServer(SafeHandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			hj, ok := w.(http.Hijacker)
+			if !ok {
+				hijacked <- errors.New("not a Hijacker")
+				return
+			}
+			conn, _, err := hj.Hijack()
+			if err == nil {
+				conn.Close()
+			}
+			hijacked <- err
+		}))
+		defer srv.Close()
+
+		resp, err := http.Get(srv.URL)
+		if err == nil {
+			resp.Body.Close()
+		}
+		assert.NoError(t, <-hijacked)
+	})
+
+	t.Run("should keep a response already started", func(t *testing.T) {
+		SetLogger(&mockLogger{})
+		defer SetLogger(&defaultLoggerImpl{})
+
+		h := SafeHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusAccepted)
+			panic("late panic")
+		}))
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, http.StatusAccepted, rec.Code)
+	})
+
+	t.Run("should pass through normal responses", func(t *testing.T) {
+		h := SafeHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("ok"))
+		}))
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "ok", rec.Body.String())
+	})
+
+	t.Run("should re-raise http.ErrAbortHandler", func(t *testing.T) {
+		h := SafeHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic(http.ErrAbortHandler)
+		}))
+		assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+			h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+		})
+	})
+}
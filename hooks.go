@@ -0,0 +1,56 @@
+package tsafe
+
+import "sync"
+
+// hookEntry wraps a registered hook so it can be removed by identity
+type hookEntry struct {
+	fn func()
+}
+
+// Thread-safe spawn hook registry
+var (
+	spawnHooks []*hookEntry
+	hooksMutex sync.RWMutex
+)
+
+// OnSpawn registers a hook called synchronously in the caller's goroutine just before each
+// goroutine is started by Go, GoWithRecover and the spawners built on them, and returns a
+// function removing it
+// Hooks run in registration order, which makes them suitable for capturing spawn-site
+// context or counting spawns. A panicking hook is recovered and never prevents the spawn
+// This function is thread-safe
+func OnSpawn(hook func()) (unregister func()) {
+	if hook == nil {
+		return func() {}
+	}
+
+	entry := &hookEntry{fn: hook}
+	hooksMutex.Lock()
+	spawnHooks = append(spawnHooks, entry)
+	hooksMutex.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			hooksMutex.Lock()
+			defer hooksMutex.Unlock()
+			for i, e := range spawnHooks {
+				if e == entry {
+					spawnHooks = append(spawnHooks[:i:i], spawnHooks[i+1:]...)
+					return
+				}
+			}
+		})
+	}
+}
+
+// runSpawnHooks calls every registered spawn hook, recovering their panics
+func runSpawnHooks() {
+	hooksMutex.RLock()
+	hooks := spawnHooks
+	hooksMutex.RUnlock()
+
+	for _, h := range hooks {
+		callSafely(h.fn)
+	}
+}
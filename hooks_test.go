@@ -0,0 +1,51 @@
+package tsafe
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOnSpawn(t *testing.T) {
+	t.Run("should run hooks in order before spawning", func(t *testing.T) {
+		var mu sync.Mutex
+		var order []string
+		record := func(s string) func() {
+			return func() {
+				mu.Lock()
+				defer mu.Unlock()
+				order = append(order, s)
+			}
+		}
+
+		defer OnSpawn(record("first"))()
+		defer OnSpawn(record("second"))()
+
+		<-GoDone(record("goroutine"))
+
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, []string{"first", "second", "goroutine"}, order)
+	})
+
+	t.Run("should spawn even if a hook panics", func(t *testing.T) {
+		defer OnSpawn(func() { panic("hook panic") })()
+
+		ran := make(chan struct{})
+		assert.NotPanics(t, func() {
+			Go(func() { close(ran) })
+		})
+		<-ran
+	})
+
+	t.Run("should stop calling removed hooks", func(t *testing.T) {
+		calls := 0
+		unregister := OnSpawn(func() { calls++ })
+		<-GoDone(func() {})
+		unregister()
+		unregister()
+		<-GoDone(func() {})
+		assert.Equal(t, 1, calls)
+	})
+}
@@ -0,0 +1,8 @@
+// Package userpanic panics from outside the tsafe package, so tsafe's own tests see the
+// stacks user code produces instead of frames that are trimmed as internal
+package userpanic
+
+// Panic panics with value
+func Panic(value any) {
+	panic(value)
+}
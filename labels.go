@@ -0,0 +1,31 @@
+package tsafe
+
+import (
+	"context"
+	"runtime/debug"
+	"runtime/pprof"
+)
+
+// GoLabeled starts a goroutine with automatic panic recovery that carries pprof labels
+// The labels group the goroutine in goroutine and CPU profiles, and are included as
+// fields in the panic report for loggers implementing FieldLogger
+func GoLabeled(labels map[string]string, goroutine func()) {
+	if goroutine == nil {
+		return // Avoid creating goroutine for nil function
+	}
+
+	pairs := make([]string, 0, len(labels)*2)
+	fields := make(map[string]any, len(labels))
+	for k, v := range labels {
+		pairs = append(pairs, k, v)
+		fields[k] = v
+	}
+
+	GoWithRecover(func() {
+		pprof.Do(context.Background(), pprof.Labels(pairs...), func(context.Context) {
+			goroutine()
+		})
+	}, func(err any) {
+		reportPanic(err, debug.Stack(), fields)
+	})
+}
@@ -0,0 +1,51 @@
+package tsafe
+
+import (
+	"bytes"
+	"runtime/pprof"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoLabeled(t *testing.T) {
+	t.Run("should show labels in goroutine profiles", func(t *testing.T) {
+		release := make(chan struct{})
+		started := make(chan struct{})
+		GoLabeled(map[string]string{"worker": "indexer"}, func() {
+			close(started)
+			<-release
+		})
+		defer close(release)
+		<-started
+
+		var buf bytes.Buffer
+		assert.NoError(t, pprof.Lookup("goroutine").WriteTo(&buf, 1))
+		assert.Contains(t, buf.String(), `"worker":"indexer"`)
+	})
+
+	t.Run("should include labels in panic fields", func(t *testing.T) {
+		mock := &mockFieldLogger{}
+		originalLogger := getLogger()
+		SetLogger(mock)
+		defer SetLogger(originalLogger)
+
+		GoLabeled(map[string]string{"worker": "indexer", "shard": "3"}, func() {
+			panic("labeled panic")
+		})
+
+		assert.Eventually(t, func() bool {
+			calls, _ := mock.getFieldCalls()
+			return calls == 1
+		}, 100*time.Millisecond, time.Millisecond)
+		_, fields := mock.getFieldCalls()
+		assert.Equal(t, map[string]any{"worker": "indexer", "shard": "3"}, fields)
+	})
+
+	t.Run("should handle nil function gracefully", func(t *testing.T) {
+		assert.NotPanics(t, func() {
+			GoLabeled(nil, nil)
+		})
+	})
+}
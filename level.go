@@ -0,0 +1,153 @@
+package tsafe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// Level is the severity assigned to a recovered panic
+type Level int32
+
+// Severity levels ordered from least to most severe
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+// String returns the lower-case name of the level
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelWarn:
+		return "warn"
+	case LevelError:
+		return "error"
+	default:
+		return fmt.Sprintf("level(%d)", int32(l))
+	}
+}
+
+// LeveledLogger is a Logger that also accepts the severity of each panic
+// When the configured logger implements it, PrintLevel is called instead of Print
+type LeveledLogger interface {
+	Logger
+	// PrintLevel logs an error and its stack trace at the given level
+	PrintLevel(level Level, err, stack any)
+}
+
+// LeveledFieldLogger is a FieldLogger that also accepts the severity of each panic
+// When the configured logger implements it and the panic carries fields, PrintLevelFields is
+// called instead of PrintFields, so the level is not lost once fields are present
+type LeveledFieldLogger interface {
+	FieldLogger
+	// PrintLevelFields logs an error and its stack trace at the given level together with structured fields
+	PrintLevelFields(level Level, err, stack any, fields map[string]any)
+}
+
+// levelAdapter lets a plain Logger be used where a LeveledLogger is expected
+type levelAdapter struct {
+	Logger
+}
+
+// PrintLevel implements the LeveledLogger interface by ignoring the level
+func (a levelAdapter) PrintLevel(level Level, err, stack any) {
+	a.Print(err, stack)
+}
+
+// NewLeveledLogger adapts a plain Logger into a LeveledLogger that logs every level through Print
+func NewLeveledLogger(l Logger) LeveledLogger {
+	if ll, ok := l.(LeveledLogger); ok {
+		return ll
+	}
+	return levelAdapter{Logger: l}
+}
+
+// Thread-safe level classification and gating
+var (
+	minLevel        int32 = int32(LevelDebug)
+	levelClassifier func(err any) Level
+	levelMutex      sync.RWMutex
+)
+
+// SetMinLevel sets the lowest level that is logged; panics classified below it are dropped
+// The default is LevelDebug, so every panic is logged
+// This function is thread-safe
+func SetMinLevel(level Level) {
+	atomic.StoreInt32(&minLevel, int32(level))
+}
+
+// SetLevelClassifier sets the function assigning a level to each recovered panic
+// Without a classifier, or when passing nil, every panic is classified as LevelError
+// This function is thread-safe
+func SetLevelClassifier(classifier func(err any) Level) {
+	levelMutex.Lock()
+	defer levelMutex.Unlock()
+	levelClassifier = classifier
+}
+
+// downgradeContextPanics classifies context cancellation panics as LevelDebug when set
+var downgradeContextPanics int32 = 1
+
+// SetDowngradeContextPanics toggles the built-in detection of panics caused by a canceled context
+// When enabled (the default), a panic whose value is an error wrapping context.Canceled or
+// context.DeadlineExceeded is classified as LevelDebug regardless of the level classifier,
+// since such panics are usually a side effect of normal shutdown. Combine with
+// SetMinLevel(LevelInfo) to drop them entirely
+// This function is thread-safe
+func SetDowngradeContextPanics(enabled bool) {
+	var v int32
+	if enabled {
+		v = 1
+	}
+	atomic.StoreInt32(&downgradeContextPanics, v)
+}
+
+// isContextPanic reports whether a panic value wraps a context cancellation error
+func isContextPanic(value any) bool {
+	err, ok := value.(error)
+	if !ok {
+		return false
+	}
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// classifyPanic returns the level of a recovered panic
+func classifyPanic(err any) Level {
+	if atomic.LoadInt32(&downgradeContextPanics) == 1 && isContextPanic(err) {
+		return LevelDebug
+	}
+	levelMutex.RLock()
+	classifier := levelClassifier
+	levelMutex.RUnlock()
+	if classifier == nil {
+		return LevelError
+	}
+	return classifier(err)
+}
+
+// logWarning logs a problem that is not a panic at LevelWarn with the configured logger
+// Sinks only receive panics, so they are skipped. As with panic reports, a panicking logger
+// is recovered and the warning handed to the dead-letter sink instead
+func logWarning(err error, stack []byte) {
+	logger := getLogger()
+	if logger == nil || !levelEnabled(LevelWarn) {
+		return
+	}
+	event := newPanicEvent(err, stack, getGlobalFields(), LevelWarn)
+	if callSafely(func() { logEvent(nil, logger, event) }) != nil {
+		deadLetter(event)
+	}
+}
+
+// levelEnabled reports whether panics at level should be logged
+func levelEnabled(level Level) bool {
+	return int32(level) >= atomic.LoadInt32(&minLevel)
+}
@@ -0,0 +1,187 @@
+package tsafe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// mockLeveledLogger records the level of every printed panic
+type mockLeveledLogger struct {
+	mockLogger
+	levels     []Level
+	levelMutex sync.Mutex
+}
+
+func (m *mockLeveledLogger) PrintLevel(level Level, err, stack any) {
+	m.levelMutex.Lock()
+	m.levels = append(m.levels, level)
+	m.levelMutex.Unlock()
+	m.Print(err, stack)
+}
+
+func (m *mockLeveledLogger) getLevels() []Level {
+	m.levelMutex.Lock()
+	defer m.levelMutex.Unlock()
+	return append([]Level(nil), m.levels...)
+}
+
+type mockLeveledFieldLogger struct {
+	mockLeveledLogger
+	fields map[string]any
+}
+
+func (m *mockLeveledFieldLogger) PrintFields(err, stack any, fields map[string]any) {
+	m.PrintLevelFields(LevelError, err, stack, fields)
+}
+
+func (m *mockLeveledFieldLogger) PrintLevelFields(level Level, err, stack any, fields map[string]any) {
+	m.levelMutex.Lock()
+	m.fields = fields
+	m.levelMutex.Unlock()
+	m.PrintLevel(level, err, stack)
+}
+
+func (m *mockLeveledFieldLogger) getFields() map[string]any {
+	m.levelMutex.Lock()
+	defer m.levelMutex.Unlock()
+	return m.fields
+}
+
+func TestLevel(t *testing.T) {
+	assert.Equal(t, "debug", LevelDebug.String())
+	assert.Equal(t, "info", LevelInfo.String())
+	assert.Equal(t, "warn", LevelWarn.String())
+	assert.Equal(t, "error", LevelError.String())
+	assert.Equal(t, "level(9)", Level(9).String())
+}
+
+func TestSetMinLevel(t *testing.T) {
+	t.Run("should pass classified levels to leveled loggers", func(t *testing.T) {
+		mock := &mockLeveledLogger{}
+		originalLogger := getLogger()
+		SetLogger(mock)
+		defer SetLogger(originalLogger)
+
+		SetLevelClassifier(func(err any) Level {
+			if err == "minor" {
+				return LevelWarn
+			}
+			return LevelError
+		})
+		defer SetLevelClassifier(nil)
+
+		Go(func() {
+			panic("minor")
+		})
+		assert.Eventually(t, func() bool {
+			return mock.getCallCount() == 1
+		}, 100*time.Millisecond, time.Millisecond)
+		assert.Equal(t, []Level{LevelWarn}, mock.getLevels())
+	})
+
+	t.Run("should pass classified levels together with fields", func(t *testing.T) {
+		mock := &mockLeveledFieldLogger{}
+		originalLogger := getLogger()
+		SetLogger(mock)
+		defer SetLogger(originalLogger)
+
+		SetLevelClassifier(func(err any) Level { return LevelWarn })
+		defer SetLevelClassifier(nil)
+
+		GoWithFields(map[string]any{"request": "r1"}, func() {
+			panic("minor")
+		})
+		assert.Eventually(t, func() bool {
+			return mock.getCallCount() == 1
+		}, 100*time.Millisecond, time.Millisecond)
+		assert.Equal(t, []Level{LevelWarn}, mock.getLevels())
+		assert.Equal(t, map[string]any{"request": "r1"}, mock.getFields())
+	})
+
+	t.Run("should drop panics below the minimum level", func(t *testing.T) {
+		mock := &mockLeveledLogger{}
+		originalLogger := getLogger()
+		SetLogger(mock)
+		defer SetLogger(originalLogger)
+
+		SetLevelClassifier(func(err any) Level {
+			if err == "minor" {
+				return LevelWarn
+			}
+			return LevelError
+		})
+		defer SetLevelClassifier(nil)
+		SetMinLevel(LevelError)
+		defer SetMinLevel(LevelDebug)
+
+		done := make(chan struct{})
+		GoWithCleanup(func() {
+			panic("minor")
+		}, func() { close(done) })
+		<-done
+
+		Go(func() {
+			panic("major")
+		})
+		assert.Eventually(t, func() bool {
+			return mock.getCallCount() == 1
+		}, 100*time.Millisecond, time.Millisecond)
+		assert.Equal(t, "major", mock.getLastError())
+		assert.Equal(t, []Level{LevelError}, mock.getLevels())
+	})
+
+	t.Run("should treat all panics as errors by default", func(t *testing.T) {
+		assert.Equal(t, LevelError, classifyPanic("anything"))
+	})
+}
+
+func TestSetDowngradeContextPanics(t *testing.T) {
+	t.Run("should classify context panics as debug", func(t *testing.T) {
+		SetDowngradeContextPanics(true)
+		assert.Equal(t, LevelDebug, classifyPanic(context.Canceled))
+		assert.Equal(t, LevelDebug, classifyPanic(fmt.Errorf("query: %w", context.DeadlineExceeded)))
+		assert.Equal(t, LevelError, classifyPanic(errors.New("other")))
+		assert.Equal(t, LevelError, classifyPanic("context canceled"))
+	})
+
+	t.Run("should drop context panics below the minimum level", func(t *testing.T) {
+		mock := &mockLogger{}
+		originalLogger := getLogger()
+		SetLogger(mock)
+		defer SetLogger(originalLogger)
+		SetMinLevel(LevelInfo)
+		defer SetMinLevel(LevelDebug)
+
+		<-GoDone(func() {
+			panic(fmt.Errorf("use after cancel: %w", context.Canceled))
+		})
+		assert.Equal(t, 0, mock.getCallCount())
+	})
+
+	t.Run("should use the classifier when disabled", func(t *testing.T) {
+		SetDowngradeContextPanics(false)
+		defer SetDowngradeContextPanics(true)
+		assert.Equal(t, LevelError, classifyPanic(context.Canceled))
+	})
+}
+
+func TestNewLeveledLogger(t *testing.T) {
+	t.Run("should adapt plain loggers", func(t *testing.T) {
+		mock := &mockLogger{}
+		leveled := NewLeveledLogger(mock)
+		leveled.PrintLevel(LevelWarn, "adapted", "stack")
+		assert.Equal(t, "adapted", mock.getLastError())
+		assert.Equal(t, 1, mock.getCallCount())
+	})
+
+	t.Run("should return leveled loggers unchanged", func(t *testing.T) {
+		mock := &mockLeveledLogger{}
+		assert.Same(t, mock, NewLeveledLogger(mock))
+	})
+}
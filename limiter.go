@@ -0,0 +1,31 @@
+package tsafe
+
+import "context"
+
+// Limiter caps the combined concurrency of every pool sharing it
+// A task runs only when both its pool's local limit and the shared limit allow it
+type Limiter struct {
+	sem *Semaphore
+}
+
+// NewLimiter creates a limiter allowing up to n tasks across all pools using it
+// A non-positive n is treated as 1
+func NewLimiter(n int) *Limiter {
+	return &Limiter{sem: NewSemaphore(n)}
+}
+
+// Running returns the number of tasks currently holding a slot of the limiter
+func (l *Limiter) Running() int {
+	acquired, _, _ := l.sem.Stats()
+	return acquired
+}
+
+// acquire blocks until a shared slot is available
+func (l *Limiter) acquire() {
+	_ = l.sem.Acquire(context.Background())
+}
+
+// release returns a shared slot
+func (l *Limiter) release() {
+	l.sem.Release()
+}
@@ -0,0 +1,60 @@
+package tsafe
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiter(t *testing.T) {
+	t.Run("should cap combined concurrency across pools", func(t *testing.T) {
+		shared := NewLimiter(3)
+		pools := []*Pool{
+			NewPoolWithLimiter(2, shared),
+			NewPoolWithLimiter(2, shared),
+			NewPoolWithLimiter(2, shared),
+		}
+
+		var running, maxRunning int32
+		task := func() {
+			n := atomic.AddInt32(&running, 1)
+			for {
+				m := atomic.LoadInt32(&maxRunning)
+				if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&running, -1)
+		}
+
+		for i := 0; i < 30; i++ {
+			pools[i%len(pools)].Submit(task)
+		}
+		for _, p := range pools {
+			p.WaitIdle()
+		}
+
+		assert.LessOrEqual(t, atomic.LoadInt32(&maxRunning), int32(3))
+		assert.Equal(t, 0, shared.Running())
+	})
+
+	t.Run("should release the shared slot after a panic", func(t *testing.T) {
+		mock := &mockLogger{}
+		originalLogger := getLogger()
+		SetLogger(mock)
+		defer SetLogger(originalLogger)
+
+		shared := NewLimiter(1)
+		pool := NewPoolWithLimiter(1, shared)
+		var completed int32
+		pool.Submit(func() { panic("limited panic") })
+		pool.Submit(func() { atomic.AddInt32(&completed, 1) })
+		pool.WaitIdle()
+
+		assert.Equal(t, int32(1), atomic.LoadInt32(&completed))
+		assert.Equal(t, 0, shared.Running())
+	})
+}
@@ -0,0 +1,23 @@
+package tsafe
+
+import "runtime"
+
+// GoLockedOSThread starts a goroutine with automatic panic recovery that runs goroutine
+// wired to a single OS thread
+// Use it for code that must stay on one thread, such as OpenGL or other C libraries using
+// thread-local state through cgo, or calls that change per-thread settings like namespaces
+// The thread is locked with runtime.LockOSThread before goroutine runs and always unlocked
+// afterwards, also when it panics or calls runtime.Goexit, before the panic is logged
+// Caveats: goroutine must not start work that needs the same thread in other goroutines, a
+// goroutine that locks the thread again itself must unlock it as often as it locked it, and
+// per-thread state changed by goroutine stays changed on the thread after it is unlocked
+func GoLockedOSThread(goroutine func()) {
+	if goroutine == nil {
+		return // Avoid creating goroutine for nil function
+	}
+	Go(func() {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+		goroutine()
+	})
+}
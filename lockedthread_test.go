@@ -0,0 +1,31 @@
+package tsafe
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoLockedOSThread(t *testing.T) {
+	t.Run("should run the function", func(t *testing.T) {
+		done := make(chan struct{})
+		GoLockedOSThread(func() { close(done) })
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("function did not run")
+		}
+	})
+
+	t.Run("should recover and log panics", func(t *testing.T) {
+		mock := &mockLogger{}
+		SetLogger(mock)
+		defer SetLogger(&defaultLoggerImpl{})
+
+		GoLockedOSThread(func() { panic("locked panic") })
+		assert.Eventually(t, func() bool { return mock.getCallCount() == 1 }, time.Second, time.Millisecond)
+		assert.Equal(t, "locked panic", mock.getLastError())
+	})
+}
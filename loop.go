@@ -0,0 +1,116 @@
+package tsafe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"time"
+)
+
+// Backoff bounds used by GoLoop between consecutive panics
+const (
+	loopMinBackoff = 10 * time.Millisecond
+	loopMaxBackoff = time.Second
+)
+
+// ErrShortRun is logged as a warning when a loop iteration ends before its minimum runtime
+var ErrShortRun = errors.New("tsafe: goroutine finished before its minimum runtime")
+
+// loopConfig holds the settings used by GoLoop
+type loopConfig struct {
+	minRuntime time.Duration
+}
+
+// LoopOption configures GoLoop
+type LoopOption func(*loopConfig)
+
+// WithMinRuntime enables flapping detection for long-running loop bodies: every call of fn
+// that returns or panics within d, unless ctx is done, logs a warning wrapping ErrShortRun with the configured
+// logger, which catches misconfigured workers and restart loops early
+// It only reports; the loop behaves the same either way. A non-positive d disables it (the default)
+func WithMinRuntime(d time.Duration) LoopOption {
+	return func(c *loopConfig) {
+		c.minRuntime = d
+	}
+}
+
+// GoLoop starts a goroutine that calls fn repeatedly until ctx is canceled or fn returns an error
+// It suits consumer loops that must survive individual message-processing panics:
+//   - returning nil runs fn again immediately
+//   - panicking logs the panic and restarts fn after a backoff that doubles on consecutive
+//     panics, from 10ms up to 1s, and resets after the next successful iteration
+//   - returning a non-nil error is the fatal stop signal: the loop ends without restarting
+//
+// The returned channel receives the reason the loop stopped, either the error returned by fn
+// or ctx.Err(), and is then closed. Callers not interested in the reason may ignore it
+func GoLoop(ctx context.Context, fn func(ctx context.Context) error, opts ...LoopOption) <-chan error {
+	stopped := make(chan error, 1)
+	if fn == nil {
+		close(stopped)
+		return stopped
+	}
+	c := &loopConfig{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(c)
+		}
+	}
+
+	spawn(func() {
+		defer close(stopped)
+		stopped <- runLoop(ctx, fn, c)
+	})
+	return stopped
+}
+
+// runLoop drives GoLoop and returns the reason it stopped
+func runLoop(ctx context.Context, fn func(ctx context.Context) error, c *loopConfig) error {
+	backoff := loopMinBackoff
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		var err error
+		start := time.Now()
+		pe := runRecovered(func() { err = fn(ctx) })
+		if ran := time.Since(start); c.minRuntime > 0 && ran < c.minRuntime && ctx.Err() == nil {
+			stack := debug.Stack()
+			if pe != nil {
+				stack = pe.Stack
+			}
+			logWarning(fmt.Errorf("%w: ran for %v, expected at least %v", ErrShortRun, ran, c.minRuntime), stack)
+		}
+		if pe == nil {
+			if err != nil {
+				return err
+			}
+			backoff = loopMinBackoff
+			continue
+		}
+
+		reportPanic(pe.Value, pe.Stack, nil)
+		if !sleepContext(ctx, backoff) {
+			return ctx.Err()
+		}
+		if backoff *= 2; backoff > loopMaxBackoff {
+			backoff = loopMaxBackoff
+		}
+	}
+}
+
+// sleepContext waits for d or until ctx is done, reporting whether the full duration elapsed
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,121 @@
+package tsafe
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoLoop(t *testing.T) {
+	t.Run("should run until the context is canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		var iterations int32
+		stopped := GoLoop(ctx, func(ctx context.Context) error {
+			if atomic.AddInt32(&iterations, 1) == 5 {
+				cancel()
+			}
+			return nil
+		})
+
+		select {
+		case err := <-stopped:
+			assert.ErrorIs(t, err, context.Canceled)
+		case <-time.After(time.Second):
+			t.Fatal("loop did not stop")
+		}
+		assert.Equal(t, int32(5), atomic.LoadInt32(&iterations))
+	})
+
+	t.Run("should stop on a returned error", func(t *testing.T) {
+		fatal := errors.New("fatal")
+		stopped := GoLoop(context.Background(), func(ctx context.Context) error {
+			return fatal
+		})
+		assert.Equal(t, fatal, <-stopped)
+
+		_, open := <-stopped
+		assert.False(t, open)
+	})
+
+	t.Run("should restart after panics with backoff", func(t *testing.T) {
+		mock := &mockLogger{}
+		originalLogger := getLogger()
+		SetLogger(mock)
+		defer SetLogger(originalLogger)
+
+		var iterations int32
+		start := time.Now()
+		stopped := GoLoop(context.Background(), func(ctx context.Context) error {
+			if atomic.AddInt32(&iterations, 1) <= 3 {
+				panic("message panic")
+			}
+			return errors.New("done")
+		})
+
+		assert.EqualError(t, <-stopped, "done")
+		assert.Equal(t, int32(4), atomic.LoadInt32(&iterations))
+		assert.Equal(t, 3, mock.getCallCount())
+		// Backoff of 10ms, 20ms and 40ms between the panics
+		assert.GreaterOrEqual(t, time.Since(start), 70*time.Millisecond)
+	})
+
+	t.Run("should interrupt the backoff on cancellation", func(t *testing.T) {
+		mock := &mockLogger{}
+		originalLogger := getLogger()
+		SetLogger(mock)
+		defer SetLogger(originalLogger)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		stopped := GoLoop(ctx, func(ctx context.Context) error {
+			cancel()
+			panic("panic before shutdown")
+		})
+
+		select {
+		case err := <-stopped:
+			assert.ErrorIs(t, err, context.Canceled)
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("backoff was not interrupted")
+		}
+	})
+
+	t.Run("should handle nil function gracefully", func(t *testing.T) {
+		_, open := <-GoLoop(context.Background(), nil)
+		assert.False(t, open)
+	})
+}
+
+func TestGoLoopMinRuntime(t *testing.T) {
+	t.Run("should warn about iterations ending too quickly", func(t *testing.T) {
+		mock := &mockLeveledLogger{}
+		SetLogger(mock)
+		defer SetLogger(&defaultLoggerImpl{})
+
+		stop := errors.New("stop")
+		stopped := GoLoop(context.Background(), func(ctx context.Context) error {
+			return stop
+		}, WithMinRuntime(time.Hour))
+		assert.Equal(t, stop, <-stopped)
+
+		assert.Equal(t, []Level{LevelWarn}, mock.getLevels())
+		assert.ErrorIs(t, mock.getLastError().(error), ErrShortRun)
+	})
+
+	t.Run("should not warn about long enough iterations", func(t *testing.T) {
+		mock := &mockLogger{}
+		SetLogger(mock)
+		defer SetLogger(&defaultLoggerImpl{})
+
+		stop := errors.New("stop")
+		stopped := GoLoop(context.Background(), func(ctx context.Context) error {
+			time.Sleep(5 * time.Millisecond)
+			return stop
+		}, WithMinRuntime(time.Millisecond))
+		assert.Equal(t, stop, <-stopped)
+		assert.Equal(t, 0, mock.getCallCount())
+	})
+}
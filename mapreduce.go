@@ -0,0 +1,111 @@
+package tsafe
+
+import "sync"
+
+// GoMap applies fn to every item concurrently and returns the results in input order
+// At most concurrency calls run at once; a non-positive value runs all items at once
+// A panicking call is logged with the configured logger and yields the zero value of R
+func GoMap[T, R any](items []T, concurrency int, fn func(T) R) []R {
+	results, _ := goMap(items, concurrency, fn)
+	return results
+}
+
+// GoMapReduce safely aggregates items in parallel as a sequential fold over a concurrent map
+// mapFn runs concurrently as in GoMap, then reduce folds the mapped values into initial
+// in input order in the calling goroutine. Items whose mapFn or reduce call panics are
+// logged and contribute nothing, so the accumulator is carried over unchanged
+// Because the fold is sequential, reduce does not need to be associative or commutative
+func GoMapReduce[T, M, R any](items []T, concurrency int, mapFn func(T) M, initial R, reduce func(R, M) R) R {
+	mapped, ok := goMap(items, concurrency, mapFn)
+
+	acc := initial
+	for i, m := range mapped {
+		if !ok[i] {
+			continue
+		}
+		next := acc
+		if pe := runRecovered(func() { next = reduce(acc, m) }); pe != nil {
+			reportPanic(pe.Value, pe.Stack, nil)
+			continue
+		}
+		acc = next
+	}
+	return acc
+}
+
+// goMap runs fn over items with bounded concurrency and reports which calls completed without panicking
+func goMap[T, R any](items []T, concurrency int, fn func(T) R) ([]R, []bool) {
+	results := make([]R, len(items))
+	ok := make([]bool, len(items))
+	if len(items) == 0 {
+		return results, ok
+	}
+	if concurrency <= 0 || concurrency > len(items) {
+		concurrency = len(items)
+	}
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, concurrency)
+	for i := range items {
+		slots <- struct{}{}
+		wg.Add(1)
+		i := i
+		spawn(func() {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			if pe := runRecovered(func() { results[i] = fn(items[i]) }); pe != nil {
+				reportPanic(pe.Value, pe.Stack, nil)
+				return
+			}
+			ok[i] = true
+		})
+	}
+	wg.Wait()
+	return results, ok
+}
+
+// GoMapStream applies fn to every item concurrently like GoMap and streams the results in input order
+// Each result is sent as soon as it and all results before it are ready; completions that
+// arrive out of order are buffered internally. A panicking call is logged and yields the
+// zero value of R in the stream. The channel is closed after the last result
+// The caller must drain the channel, otherwise the goroutines feeding it never exit
+func GoMapStream[T, R any](items []T, concurrency int, fn func(T) R) <-chan R {
+	if concurrency <= 0 || concurrency > len(items) {
+		concurrency = len(items)
+	}
+	out := make(chan R, concurrency)
+	if len(items) == 0 {
+		close(out)
+		return out
+	}
+
+	ready := make([]chan R, len(items))
+	for i := range ready {
+		ready[i] = make(chan R, 1)
+	}
+
+	spawn(func() {
+		slots := make(chan struct{}, concurrency)
+		for i := range items {
+			slots <- struct{}{}
+			i := i
+			spawn(func() {
+				defer func() { <-slots }()
+				var result R
+				if pe := runRecovered(func() { result = fn(items[i]) }); pe != nil {
+					reportPanic(pe.Value, pe.Stack, nil)
+				}
+				ready[i] <- result
+			})
+		}
+	})
+	spawn(func() {
+		defer close(out)
+		for _, ch := range ready {
+			out <- <-ch
+		}
+	})
+	return out
+}
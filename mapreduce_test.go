@@ -0,0 +1,155 @@
+package tsafe
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoMap(t *testing.T) {
+	t.Run("should preserve input order", func(t *testing.T) {
+		results := GoMap([]int{1, 2, 3, 4, 5}, 2, func(i int) int {
+			time.Sleep(time.Duration(5-i) * time.Millisecond)
+			return i * i
+		})
+		assert.Equal(t, []int{1, 4, 9, 16, 25}, results)
+	})
+
+	t.Run("should limit concurrency", func(t *testing.T) {
+		var running, maxRunning int32
+		GoMap(make([]int, 20), 3, func(int) int {
+			n := atomic.AddInt32(&running, 1)
+			for {
+				m := atomic.LoadInt32(&maxRunning)
+				if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			return 0
+		})
+		assert.LessOrEqual(t, atomic.LoadInt32(&maxRunning), int32(3))
+	})
+
+	t.Run("should yield zero values for panicking items", func(t *testing.T) {
+		mock := &mockLogger{}
+		originalLogger := getLogger()
+		SetLogger(mock)
+		defer SetLogger(originalLogger)
+
+		results := GoMap([]int{1, 2, 3}, 0, func(i int) int {
+			if i == 2 {
+				panic("map panic")
+			}
+			return i * 10
+		})
+		assert.Equal(t, []int{10, 0, 30}, results)
+		assert.Equal(t, 1, mock.getCallCount())
+	})
+
+	t.Run("should handle empty input", func(t *testing.T) {
+		assert.Empty(t, GoMap(nil, 4, func(i int) int { return i }))
+	})
+}
+
+func TestGoMapReduce(t *testing.T) {
+	t.Run("should fold mapped values", func(t *testing.T) {
+		sum := GoMapReduce([]int{1, 2, 3, 4}, 2, func(i int) int {
+			return i * i
+		}, 0, func(acc, v int) int {
+			return acc + v
+		})
+		assert.Equal(t, 30, sum)
+	})
+
+	t.Run("should fold in input order", func(t *testing.T) {
+		joined := GoMapReduce([]string{"a", "b", "c"}, 0, func(s string) string {
+			return s + s
+		}, "", func(acc, v string) string {
+			return acc + v
+		})
+		assert.Equal(t, "aabbcc", joined)
+	})
+
+	t.Run("should skip items that panic in map or reduce", func(t *testing.T) {
+		mock := &mockLogger{}
+		originalLogger := getLogger()
+		SetLogger(mock)
+		defer SetLogger(originalLogger)
+
+		sum := GoMapReduce([]int{1, 2, 3, 4}, 0, func(i int) int {
+			if i == 2 {
+				panic("map panic")
+			}
+			return i
+		}, 0, func(acc, v int) int {
+			if v == 4 {
+				panic("reduce panic")
+			}
+			return acc + v
+		})
+		assert.Equal(t, 4, sum)
+		assert.Equal(t, 2, mock.getCallCount())
+	})
+}
+
+func TestGoMapStream(t *testing.T) {
+	t.Run("should stream results in input order", func(t *testing.T) {
+		items := []int{5, 4, 3, 2, 1}
+		var got []int
+		for r := range GoMapStream(items, 0, func(n int) int {
+			time.Sleep(time.Duration(n) * time.Millisecond)
+			return n * 10
+		}) {
+			got = append(got, r)
+		}
+		assert.Equal(t, []int{50, 40, 30, 20, 10}, got)
+	})
+
+	t.Run("should emit a ready prefix before later items finish", func(t *testing.T) {
+		release := make(chan struct{})
+		stream := GoMapStream([]int{1, 2}, 2, func(n int) int {
+			if n == 2 {
+				<-release
+			}
+			return n
+		})
+
+		select {
+		case r := <-stream:
+			assert.Equal(t, 1, r)
+		case <-time.After(time.Second):
+			t.Fatal("first result was not streamed")
+		}
+		close(release)
+		assert.Equal(t, 2, <-stream)
+		_, open := <-stream
+		assert.False(t, open)
+	})
+
+	t.Run("should log panics and stream the zero value", func(t *testing.T) {
+		mock := &mockLogger{}
+		SetLogger(mock)
+		defer SetLogger(&defaultLoggerImpl{})
+
+		var got []string
+		for r := range GoMapStream([]string{"a", "boom", "c"}, 1, func(s string) string {
+			if s == "boom" {
+				panic("stream panic")
+			}
+			return s
+		}) {
+			got = append(got, r)
+		}
+		assert.Equal(t, []string{"a", "", "c"}, got)
+		assert.Equal(t, 1, mock.getCallCount())
+	})
+
+	t.Run("should close immediately without items", func(t *testing.T) {
+		_, open := <-GoMapStream(nil, 2, func(n int) int { return n })
+		assert.False(t, open)
+	})
+}
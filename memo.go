@@ -0,0 +1,70 @@
+package tsafe
+
+import "sync"
+
+// memoCall is an in-flight or successfully completed Memo computation
+type memoCall[V any] struct {
+	done chan struct{}
+	val  V
+	err  error
+}
+
+// Memo computes a value once per key and caches it, for lazily initialized shared resources
+// Concurrent callers for a key wait for the single in-flight computation and all receive its
+// result. Only successful results are cached: an error, or a panic returned as *PanicError,
+// is handed to the waiting callers and the next call for the key computes again. If fn calls
+// runtime.Goexit, the waiting callers get ErrGoexit
+// The zero value is ready to use
+type Memo[K comparable, V any] struct {
+	mu    sync.Mutex
+	calls map[K]*memoCall[V]
+}
+
+// Do returns the cached value for key, computing it with fn if there is none yet
+// fn runs in the calling goroutine of the first caller, with panic recovery
+func (m *Memo[K, V]) Do(key K, fn func() (V, error)) (V, error) {
+	m.mu.Lock()
+	if m.calls == nil {
+		m.calls = make(map[K]*memoCall[V])
+	}
+	if c, ok := m.calls[key]; ok {
+		m.mu.Unlock()
+		<-c.done
+		return c.val, c.err
+	}
+	c := &memoCall[V]{done: make(chan struct{})}
+	m.calls[key] = c
+	m.mu.Unlock()
+
+	// The cleanup is deferred so it also runs when fn calls runtime.Goexit
+	completed := false
+	defer func() {
+		if !completed {
+			var zero V
+			c.val, c.err = zero, ErrGoexit
+		}
+		if c.err != nil {
+			// Do not cache failures, so they can be retried
+			m.mu.Lock()
+			if m.calls[key] == c {
+				delete(m.calls, key)
+			}
+			m.mu.Unlock()
+		}
+		close(c.done)
+	}()
+	if pe := runRecovered(func() { c.val, c.err = fn() }); pe != nil {
+		var zero V
+		c.val, c.err = zero, pe
+	}
+	completed = true
+	return c.val, c.err
+}
+
+// Forget drops the cached value for key, so the next Do computes it again
+// An in-flight computation is not affected, but its result is not cached for later callers
+func (m *Memo[K, V]) Forget(key K) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.calls, key)
+}
@@ -0,0 +1,119 @@
+package tsafe
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemo(t *testing.T) {
+	t.Run("should compute once per key", func(t *testing.T) {
+		var m Memo[string, int]
+		var calls int32
+		compute := func() (int, error) {
+			return int(atomic.AddInt32(&calls, 1)), nil
+		}
+
+		a, err := m.Do("a", compute)
+		assert.NoError(t, err)
+		again, _ := m.Do("a", compute)
+		b, _ := m.Do("b", compute)
+		assert.Equal(t, 1, a)
+		assert.Equal(t, 1, again)
+		assert.Equal(t, 2, b)
+	})
+
+	t.Run("should share an in-flight computation", func(t *testing.T) {
+		var m Memo[string, int]
+		var calls int32
+		release := make(chan struct{})
+
+		var wg sync.WaitGroup
+		results := make([]int, 10)
+		for i := range results {
+			i := i
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				results[i], _ = m.Do("key", func() (int, error) {
+					atomic.AddInt32(&calls, 1)
+					<-release
+					return 7, nil
+				})
+			}()
+		}
+		time.Sleep(10 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+		for _, r := range results {
+			assert.Equal(t, 7, r)
+		}
+	})
+
+	t.Run("should release waiters when fn calls runtime.Goexit", func(t *testing.T) {
+		var m Memo[string, int]
+		release := make(chan struct{})
+		started := make(chan struct{})
+		exited := make(chan struct{})
+		go func() {
+			defer close(exited)
+			_, _ = m.Do("key", func() (int, error) {
+				close(started)
+				<-release
+				runtime.Goexit()
+				return 0, nil
+			})
+		}()
+		<-started
+
+		waiter := make(chan error, 1)
+		go func() {
+			_, err := m.Do("key", func() (int, error) { return 1, nil })
+			waiter <- err
+		}()
+		time.Sleep(50 * time.Millisecond)
+		close(release)
+		<-exited
+
+		select {
+		case err := <-waiter:
+			assert.ErrorIs(t, err, ErrGoexit)
+		case <-time.After(time.Second):
+			t.Fatal("waiter blocked after runtime.Goexit")
+		}
+		v, err := m.Do("key", func() (int, error) { return 2, nil })
+		assert.NoError(t, err)
+		assert.Equal(t, 2, v)
+	})
+
+	t.Run("should not cache errors and panics", func(t *testing.T) {
+		var m Memo[int, string]
+		_, err := m.Do(1, func() (string, error) { return "", errors.New("not ready") })
+		assert.EqualError(t, err, "not ready")
+
+		_, err = m.Do(1, func() (string, error) { panic("init panic") })
+		var pe *PanicError
+		if assert.True(t, errors.As(err, &pe)) {
+			assert.Equal(t, "init panic", pe.Value)
+		}
+
+		v, err := m.Do(1, func() (string, error) { return "ready", nil })
+		assert.NoError(t, err)
+		assert.Equal(t, "ready", v)
+	})
+
+	t.Run("should recompute after Forget", func(t *testing.T) {
+		var m Memo[string, int]
+		_, _ = m.Do("a", func() (int, error) { return 1, nil })
+		m.Forget("a")
+		v, _ := m.Do("a", func() (int, error) { return 2, nil })
+		assert.Equal(t, 2, v)
+	})
+}
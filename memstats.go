@@ -0,0 +1,81 @@
+package tsafe
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// memStatsInterval is how long a memory snapshot is reused, bounding ReadMemStats calls during panic storms
+const memStatsInterval = time.Second
+
+// MemStats is a snapshot of memory statistics taken when a panic is recovered
+type MemStats struct {
+	// Alloc is the number of bytes of allocated heap objects
+	Alloc uint64
+	// HeapInuse is the number of bytes in in-use heap spans
+	HeapInuse uint64
+	// NumGoroutine is the number of goroutines that existed
+	NumGoroutine int
+}
+
+// String renders the snapshot as a compact summary
+func (m *MemStats) String() string {
+	return fmt.Sprintf("alloc=%d heap_inuse=%d goroutines=%d", m.Alloc, m.HeapInuse, m.NumGoroutine)
+}
+
+// includeMemStats is 1 when panic events carry memory statistics
+var includeMemStats int32
+
+// Cached memory snapshot
+var (
+	memStatsSnapshot *MemStats
+	memStatsTaken    time.Time
+	memStatsMutex    sync.Mutex
+)
+
+// SetIncludeMemStats makes every PanicEvent carry a MemStats snapshot, to correlate panics
+// with memory pressure; the default logger then appends it as a "Memory:" line
+// Reading memory statistics stops the world, so it is off by default, and during panic
+// storms a snapshot is reused for up to a second instead of being read for every panic
+// This function is thread-safe
+func SetIncludeMemStats(on bool) {
+	var v int32
+	if on {
+		v = 1
+	}
+	atomic.StoreInt32(&includeMemStats, v)
+}
+
+// currentMemStats returns a recent memory snapshot, or nil unless SetIncludeMemStats is on
+func currentMemStats() *MemStats {
+	if atomic.LoadInt32(&includeMemStats) == 0 {
+		return nil
+	}
+	memStatsMutex.Lock()
+	defer memStatsMutex.Unlock()
+	if memStatsSnapshot != nil && time.Since(memStatsTaken) < memStatsInterval {
+		return memStatsSnapshot
+	}
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	memStatsSnapshot = &MemStats{
+		Alloc:        ms.Alloc,
+		HeapInuse:    ms.HeapInuse,
+		NumGoroutine: runtime.NumGoroutine(),
+	}
+	memStatsTaken = time.Now()
+	return memStatsSnapshot
+}
+
+// formatMemStats renders the current memory snapshot as a "Memory:" line preceded by a
+// newline, or returns an empty string unless SetIncludeMemStats is on
+func formatMemStats() string {
+	ms := currentMemStats()
+	if ms == nil {
+		return ""
+	}
+	return "\nMemory: " + ms.String()
+}
@@ -0,0 +1,54 @@
+package tsafe
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetIncludeMemStats(t *testing.T) {
+	defer SetIncludeMemStats(false)
+
+	t.Run("should not snapshot memory by default", func(t *testing.T) {
+		event := newPanicEvent("boom", nil, nil, LevelError)
+		assert.Nil(t, event.MemStats)
+	})
+
+	t.Run("should add a memory snapshot to panic events", func(t *testing.T) {
+		SetIncludeMemStats(true)
+		event := newPanicEvent("boom", nil, nil, LevelError)
+		if assert.NotNil(t, event.MemStats) {
+			assert.NotZero(t, event.MemStats.Alloc)
+			assert.NotZero(t, event.MemStats.HeapInuse)
+			assert.Greater(t, event.MemStats.NumGoroutine, 0)
+		}
+	})
+
+	t.Run("should reuse a recent snapshot", func(t *testing.T) {
+		SetIncludeMemStats(true)
+		assert.Same(t, currentMemStats(), currentMemStats())
+	})
+
+	t.Run("should append a summary line in the default logger", func(t *testing.T) {
+		SetIncludeMemStats(true)
+		out := captureLogOutput(func() {
+			(&defaultLoggerImpl{}).Print("boom", []byte("stack"))
+		})
+		assert.Contains(t, out, "\nMemory: alloc=")
+		assert.Contains(t, out, "goroutines=")
+	})
+
+	t.Run("should add the snapshot to JSON entries", func(t *testing.T) {
+		SetIncludeMemStats(true)
+		SetDefaultLoggerFormat(FormatJSON)
+		defer SetDefaultLoggerFormat(FormatText)
+
+		out := captureLogOutput(func() {
+			(&defaultLoggerImpl{}).Print("boom", []byte("stack"))
+		})
+		var entry map[string]any
+		assert.NoError(t, json.Unmarshal([]byte(out), &entry))
+		assert.Contains(t, entry["memory"], "HeapInuse")
+	})
+}
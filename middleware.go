@@ -0,0 +1,69 @@
+package tsafe
+
+import "sync"
+
+// middlewareEntry wraps a registered middleware so it can be removed by identity
+type middlewareEntry struct {
+	wrap func(next func()) func()
+}
+
+// Thread-safe middleware registry
+var (
+	middlewares     []*middlewareEntry
+	middlewareMutex sync.RWMutex
+)
+
+// Use registers a middleware wrapping every function started by Go, GoWithRecover and the
+// spawners built on them, and returns a function removing it
+// A middleware receives the next function of the chain and returns its replacement, which
+// runs in the spawned goroutine; it can set up context, time the run or add pprof labels
+// around a call to next. Middlewares compose in registration order, the first registered
+// being the outermost layer. A panic anywhere in the chain is recovered like one in the function,
+// and a panic while wrapping is reported and that middleware skipped
+// A middleware must call next exactly once, synchronously. One that does not call next skips
+// the function; helpers waiting on it, such as GoDone and GoTagged, then release as if it ran
+// This function is thread-safe; goroutines already started keep the chain they were started with
+func Use(middleware func(next func()) func()) (unregister func()) {
+	if middleware == nil {
+		return func() {}
+	}
+
+	entry := &middlewareEntry{wrap: middleware}
+	middlewareMutex.Lock()
+	middlewares = append(middlewares, entry)
+	middlewareMutex.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			middlewareMutex.Lock()
+			defer middlewareMutex.Unlock()
+			for i, e := range middlewares {
+				if e == entry {
+					middlewares = append(middlewares[:i:i], middlewares[i+1:]...)
+					return
+				}
+			}
+		})
+	}
+}
+
+// applyMiddleware wraps fn in the registered middlewares
+func applyMiddleware(fn func()) func() {
+	middlewareMutex.RLock()
+	chain := middlewares
+	middlewareMutex.RUnlock()
+
+	// Wrap from the innermost layer outwards so the first registered middleware runs first
+	// A middleware panicking while wrapping is reported and skipped, like one returning nil
+	for i := len(chain) - 1; i >= 0; i-- {
+		var wrapped func()
+		if pe := runRecovered(func() { wrapped = chain[i].wrap(fn) }); pe != nil {
+			reportPanic(pe.Value, pe.Stack, nil)
+		}
+		if wrapped != nil {
+			fn = wrapped
+		}
+	}
+	return fn
+}
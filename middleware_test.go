@@ -0,0 +1,116 @@
+package tsafe
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUse(t *testing.T) {
+	t.Run("should wrap spawned functions in registration order", func(t *testing.T) {
+		var mu sync.Mutex
+		var calls []string
+		record := func(s string) {
+			mu.Lock()
+			defer mu.Unlock()
+			calls = append(calls, s)
+		}
+		layer := func(name string) func(next func()) func() {
+			return func(next func()) func() {
+				return func() {
+					record(name + " before")
+					next()
+					record(name + " after")
+				}
+			}
+		}
+		defer Use(layer("outer"))()
+		defer Use(layer("inner"))()
+
+		done := make(chan struct{})
+		Go(func() {
+			record("fn")
+			close(done)
+		})
+		<-done
+		waitForNoActiveGoroutines(t)
+
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, []string{"outer before", "inner before", "fn", "inner after", "outer after"}, calls)
+	})
+
+	t.Run("should recover panics raised by a middleware", func(t *testing.T) {
+		mock := &mockLogger{}
+		SetLogger(mock)
+		defer SetLogger(&defaultLoggerImpl{})
+		defer Use(func(next func()) func() {
+			return func() { panic("middleware panic") }
+		})()
+
+		Go(func() {})
+		assert.Eventually(t, func() bool { return mock.getCallCount() == 1 }, time.Second, time.Millisecond)
+		assert.Equal(t, "middleware panic", mock.getLastError())
+	})
+
+	t.Run("should report and skip middlewares that fail to wrap", func(t *testing.T) {
+		mock := &mockLogger{}
+		SetLogger(mock)
+		defer SetLogger(&defaultLoggerImpl{})
+		defer Use(func(next func()) func() { panic("wrap panic") })()
+		defer Use(func(next func()) func() { return nil })()
+
+		done := make(chan struct{})
+		Go(func() { close(done) })
+		<-done
+		assert.Equal(t, 1, mock.getCallCount())
+		assert.Equal(t, "wrap panic", mock.getLastError())
+	})
+
+	t.Run("should release helpers when a middleware skips next", func(t *testing.T) {
+		defer Use(func(next func()) func() { return func() {} })()
+		SetTagLimit("skipped", 1)
+		defer SetTagLimit("skipped", 0)
+
+		select {
+		case <-GoDone(func() {}):
+		case <-time.After(time.Second):
+			t.Fatal("done channel was not closed")
+		}
+
+		GoTagged("skipped", func(ctx context.Context) {})
+		acquired := make(chan struct{})
+		go func() {
+			GoTagged("skipped", func(ctx context.Context) {})
+			close(acquired)
+		}()
+		select {
+		case <-acquired:
+		case <-time.After(time.Second):
+			t.Fatal("slot leaked by the skipped function")
+		}
+	})
+
+	t.Run("should stop applying a removed middleware", func(t *testing.T) {
+		var wrapped int32
+		var mu sync.Mutex
+		unregister := Use(func(next func()) func() {
+			mu.Lock()
+			wrapped++
+			mu.Unlock()
+			return next
+		})
+		unregister()
+		unregister()
+
+		done := make(chan struct{})
+		Go(func() { close(done) })
+		<-done
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Zero(t, wrapped)
+	})
+}
@@ -0,0 +1,28 @@
+package tsafe
+
+import "runtime/debug"
+
+// nameField is the field key carrying the logical name of a goroutine in panic reports
+const nameField = "name"
+
+// GoNamed starts a goroutine with automatic panic recovery identified by a logical task name
+// The name is included in the panic report as the "name" field for loggers implementing FieldLogger
+func GoNamed(name string, goroutine func()) {
+	fields := map[string]any{nameField: name}
+	GoWithRecover(trackGoroutine(name, "", goroutine), func(err any) {
+		reportPanic(err, debug.Stack(), fields)
+	})
+}
+
+// GoNamedWithRecover starts a named goroutine with custom panic recovery handling
+// The recover callback receives the goroutine's name along with the panic value, which
+// makes it easy to key failures by logical task. A nil callback behaves like GoNamed
+func GoNamedWithRecover(name string, goroutine func(), customRecover func(name string, err any)) {
+	if customRecover == nil {
+		GoNamed(name, goroutine)
+		return
+	}
+	GoWithRecover(trackGoroutine(name, "", goroutine), func(err any) {
+		customRecover(name, err)
+	})
+}
@@ -0,0 +1,67 @@
+package tsafe
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoNamed(t *testing.T) {
+	t.Run("should include the name in panic fields", func(t *testing.T) {
+		mock := &mockFieldLogger{}
+		originalLogger := getLogger()
+		SetLogger(mock)
+		defer SetLogger(originalLogger)
+
+		GoNamed("indexer", func() {
+			panic("named panic")
+		})
+
+		assert.Eventually(t, func() bool {
+			calls, _ := mock.getFieldCalls()
+			return calls == 1
+		}, 100*time.Millisecond, time.Millisecond)
+		_, fields := mock.getFieldCalls()
+		assert.Equal(t, "indexer", fields["name"])
+	})
+
+	t.Run("should handle nil function gracefully", func(t *testing.T) {
+		assert.NotPanics(t, func() {
+			GoNamed("nil", nil)
+		})
+	})
+}
+
+func TestGoNamedWithRecover(t *testing.T) {
+	t.Run("should pass the name to the recover callback", func(t *testing.T) {
+		type failure struct {
+			name string
+			err  any
+		}
+		failures := make(chan failure, 1)
+
+		GoNamedWithRecover("mailer", func() {
+			panic("smtp down")
+		}, func(name string, err any) {
+			failures <- failure{name: name, err: err}
+		})
+
+		select {
+		case f := <-failures:
+			assert.Equal(t, "mailer", f.name)
+			assert.Equal(t, "smtp down", f.err)
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("recover callback was not called")
+		}
+	})
+
+	t.Run("should handle nil recover function", func(t *testing.T) {
+		assert.NotPanics(t, func() {
+			GoNamedWithRecover("nil", func() {
+				panic("test panic")
+			}, nil)
+		})
+		time.Sleep(10 * time.Millisecond)
+	})
+}
@@ -0,0 +1,32 @@
+package tsafe
+
+import "context"
+
+// panicWaiter is a sink handing the first event it receives to one NextPanic call
+type panicWaiter chan PanicEvent
+
+// Emit implements the Sink interface without ever blocking the recovering goroutine
+func (w panicWaiter) Emit(event PanicEvent) {
+	select {
+	case w <- event:
+	default:
+	}
+}
+
+// NextPanic blocks until the next panic reported by Go or any other logging spawner and returns it
+// Every call is its own subscription, so several watchers observe the same panic. Panics
+// handled by a custom recover function, suppressed by SetLogFilter or below SetMinLevel are
+// not reported and therefore not returned. It returns ctx.Err() if ctx ends first, and the
+// subscription is removed in either case
+func NextPanic(ctx context.Context) (*PanicError, error) {
+	waiter := make(panicWaiter, 1)
+	unregister := RegisterSink(waiter)
+	defer unregister()
+
+	select {
+	case event := <-waiter:
+		return newPanicError(event.Value, event.Stack), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
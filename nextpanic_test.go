@@ -0,0 +1,75 @@
+package tsafe
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// sinkCount returns the number of registered sinks
+func sinkCount() int {
+	return len(getSinks())
+}
+
+func TestNextPanic(t *testing.T) {
+	t.Run("should ignore warnings", func(t *testing.T) {
+		SetLogger(&mockLogger{})
+		defer SetLogger(&defaultLoggerImpl{})
+
+		result := make(chan *PanicError, 1)
+		go func() {
+			pe, _ := NextPanic(context.Background())
+			result <- pe
+		}()
+		assert.Eventually(t, func() bool { return sinkCount() == 1 }, time.Second, time.Millisecond)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		SetShutdownContext(ctx)
+		Go(func() {})
+		SetShutdownContext(nil)
+
+		Go(func() { panic("real panic") })
+		assert.Equal(t, "real panic", (<-result).Value)
+	})
+
+	t.Run("should deliver the next panic to every watcher", func(t *testing.T) {
+		SetLogger(&mockLogger{})
+		defer SetLogger(&defaultLoggerImpl{})
+
+		var wg sync.WaitGroup
+		results := make(chan *PanicError, 2)
+		for i := 0; i < 2; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				pe, err := NextPanic(context.Background())
+				assert.NoError(t, err)
+				results <- pe
+			}()
+		}
+		assert.Eventually(t, func() bool { return sinkCount() == 2 }, time.Second, time.Millisecond)
+
+		Go(func() { panic("watched panic") })
+		wg.Wait()
+		for i := 0; i < 2; i++ {
+			pe := <-results
+			assert.Equal(t, "watched panic", pe.Value)
+			assert.NotEmpty(t, pe.Stack)
+		}
+		assert.Equal(t, 0, sinkCount())
+	})
+
+	t.Run("should return the context error and unsubscribe", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		pe, err := NextPanic(ctx)
+		assert.Nil(t, pe)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, 0, sinkCount())
+	})
+}
@@ -0,0 +1,31 @@
+package tsafe
+
+// NilPanicError is the panic value reported when a goroutine calls panic(nil)
+// Recovery always reports it, both when recover returns nil (Go before 1.21, or
+// GODEBUG=panicnil=1) and when it returns a *runtime.PanicNilError (Go 1.21+), so
+// loggers and PanicError.Value never see a bare nil
+type NilPanicError struct {
+	// cause is the *runtime.PanicNilError recovered on Go 1.21+, if any
+	cause error
+}
+
+// Error implements the error interface
+func (e *NilPanicError) Error() string {
+	return "panic called with nil argument"
+}
+
+// Unwrap returns the *runtime.PanicNilError recovered on Go 1.21+, or nil
+func (e *NilPanicError) Unwrap() error {
+	return e.cause
+}
+
+// normalizePanicValue replaces nil panic values with a *NilPanicError
+func normalizePanicValue(value any) any {
+	if value == nil {
+		return &NilPanicError{}
+	}
+	if isRuntimePanicNil(value) {
+		return &NilPanicError{cause: value.(error)}
+	}
+	return value
+}
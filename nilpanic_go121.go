@@ -0,0 +1,11 @@
+//go:build go1.21
+
+package tsafe
+
+import "runtime"
+
+// isRuntimePanicNil reports whether value is the *runtime.PanicNilError produced by panic(nil)
+func isRuntimePanicNil(value any) bool {
+	_, ok := value.(*runtime.PanicNilError)
+	return ok
+}
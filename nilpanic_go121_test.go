@@ -0,0 +1,23 @@
+//go:build go1.21
+
+package tsafe
+
+import (
+	"errors"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeRuntimePanicNil(t *testing.T) {
+	runtimeErr := new(runtime.PanicNilError)
+	value := normalizePanicValue(runtimeErr)
+
+	npe, ok := value.(*NilPanicError)
+	if assert.True(t, ok) {
+		var target *runtime.PanicNilError
+		assert.True(t, errors.As(npe, &target))
+		assert.Same(t, runtimeErr, target)
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !go1.21
+
+package tsafe
+
+// isRuntimePanicNil reports whether value is the *runtime.PanicNilError produced by panic(nil)
+// Before Go 1.21 recover returns nil for panic(nil), so no value matches
+func isRuntimePanicNil(value any) bool {
+	return false
+}
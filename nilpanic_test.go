@@ -0,0 +1,57 @@
+package tsafe
+
+import (
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNilPanic(t *testing.T) {
+	t.Run("should log a NilPanicError for panic(nil)", func(t *testing.T) {
+		mock := &mockLogger{}
+		originalLogger := getLogger()
+		SetLogger(mock)
+		defer SetLogger(originalLogger)
+
+		Go(func() {
+			panic(nil)
+		})
+
+		assert.Eventually(t, func() bool {
+			return mock.getCallCount() == 1
+		}, 100*time.Millisecond, time.Millisecond)
+		assert.IsType(t, &NilPanicError{}, mock.getLastError())
+	})
+
+	t.Run("should never produce a PanicError with a nil value", func(t *testing.T) {
+		pe := runRecovered(func() {
+			panic(nil)
+		})
+		if assert.NotNil(t, pe) {
+			var npe *NilPanicError
+			assert.True(t, errors.As(pe, &npe))
+			assert.Equal(t, "panic called with nil argument", npe.Error())
+		}
+	})
+
+	t.Run("should not treat runtime.Goexit as a panic", func(t *testing.T) {
+		called := make(chan any, 1)
+		done := make(chan struct{})
+		GoWithRecover(func() {
+			defer close(done)
+			runtime.Goexit()
+		}, func(err any) {
+			called <- err
+		})
+		<-done
+		time.Sleep(10 * time.Millisecond)
+		assert.Len(t, called, 0)
+	})
+
+	t.Run("should leave non-nil values untouched", func(t *testing.T) {
+		assert.Equal(t, "boom", normalizePanicValue("boom"))
+	})
+}
@@ -0,0 +1,38 @@
+package tsafe
+
+import (
+	"sync"
+	"time"
+)
+
+// overheadRuns is the number of recovered no-op calls timed by RecoveryOverhead
+const overheadRuns = 10000
+
+// Recovery overhead estimate, measured once
+var (
+	recoveryOverhead     time.Duration
+	recoveryOverheadOnce sync.Once
+)
+
+// RecoveryOverhead returns an estimate of the time tsafe's panic recovery adds to each goroutine,
+// on top of the cost of the go statement itself, to help decide whether tsafe fits a hot path
+// The estimate is informational: it is measured once, on the first call, by timing a no-op
+// run through the recovery path, and includes none of the logging done when a panic occurs
+// This function is thread-safe
+func RecoveryOverhead() time.Duration {
+	recoveryOverheadOnce.Do(func() {
+		recoveryOverhead = measureRecoveryOverhead(overheadRuns)
+	})
+	return recoveryOverhead
+}
+
+// measureRecoveryOverhead returns the average duration of n recovered no-op calls
+func measureRecoveryOverhead(n int) time.Duration {
+	noop := func() {}
+	discard := func(err any) {}
+	start := time.Now()
+	for i := 0; i < n; i++ {
+		runWithRecover(noop, discard)
+	}
+	return time.Since(start) / time.Duration(n)
+}
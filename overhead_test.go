@@ -0,0 +1,38 @@
+package tsafe
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecoveryOverhead(t *testing.T) {
+	t.Run("should return a stable positive estimate", func(t *testing.T) {
+		overhead := RecoveryOverhead()
+		assert.Greater(t, overhead, time.Duration(0))
+		assert.Less(t, overhead, time.Millisecond)
+		assert.Equal(t, overhead, RecoveryOverhead())
+	})
+}
+
+func TestRecoveryPathAllocs(t *testing.T) {
+	t.Run("should not allocate when the goroutine does not panic", func(t *testing.T) {
+		noop := func() {}
+		allocs := testing.AllocsPerRun(1000, func() {
+			runWithRecover(noop, logPanic)
+		})
+		assert.Zero(t, allocs)
+	})
+}
+
+func BenchmarkRecoveryPath(b *testing.B) {
+	noop := func() {}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		runWithRecover(noop, logPanic)
+	}
+	if allocs := testing.AllocsPerRun(100, func() { runWithRecover(noop, logPanic) }); allocs != 0 {
+		b.Fatalf("recovery path allocated %v times per run, want 0", allocs)
+	}
+}
@@ -0,0 +1,78 @@
+package tsafe
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+)
+
+// unknownPackage is the attribution of panics whose origin cannot be determined from the stack
+const unknownPackage = "unknown"
+
+// Thread-safe per-package panic counters
+var (
+	panicsByPackage = make(map[string]uint64)
+	panicsPkgMutex  sync.Mutex
+)
+
+// PanicsByPackage returns the number of recovered panics per originating package
+// Each panic is attributed to the import path of the package containing the top frame
+// below the panic call that belongs neither to tsafe nor to the runtime, the same frame
+// SetTrimInternalFrames shows first. Panics without a usable stack, such as panic(nil),
+// are counted under "unknown". The returned map is a copy
+// This function is thread-safe
+func PanicsByPackage() map[string]uint64 {
+	panicsPkgMutex.Lock()
+	defer panicsPkgMutex.Unlock()
+	counts := make(map[string]uint64, len(panicsByPackage))
+	for pkg, n := range panicsByPackage {
+		counts[pkg] = n
+	}
+	return counts
+}
+
+// countPanicPackage attributes one panic to the package it originated from
+func countPanicPackage(stack []byte) {
+	pkg := panicPackage(stack)
+	panicsPkgMutex.Lock()
+	panicsByPackage[pkg]++
+	panicsPkgMutex.Unlock()
+}
+
+// panicPackage finds the originating package of a panic in a stack in debug.Stack format
+func panicPackage(stack []byte) string {
+	lines := bytes.Split(stack, []byte("\n"))
+	afterPanic := false
+	for _, line := range lines {
+		if len(line) == 0 || line[0] == '\t' || bytes.HasPrefix(line, []byte("goroutine ")) {
+			continue
+		}
+		function := string(line)
+		if strings.HasPrefix(function, "panic(") {
+			afterPanic = true
+			continue
+		}
+		if !afterPanic || strings.HasPrefix(function, "runtime.") {
+			continue
+		}
+		if isInternalFrame(function) {
+			continue
+		}
+		return functionPackage(function)
+	}
+	return unknownPackage
+}
+
+// functionPackage extracts the import path from a stack frame's function line,
+// e.g. "example.com/app/db.(*Conn).Query(...)" yields "example.com/app/db"
+func functionPackage(function string) string {
+	function = strings.TrimPrefix(function, "created by ")
+	dir, name := "", function
+	if i := strings.LastIndex(function, "/"); i >= 0 {
+		dir, name = function[:i+1], function[i+1:]
+	}
+	if i := strings.Index(name, "."); i >= 0 {
+		name = name[:i]
+	}
+	return dir + name
+}
@@ -0,0 +1,45 @@
+package tsafe
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tinystack/tsafe/internal/userpanic"
+)
+
+func TestPanicsByPackage(t *testing.T) {
+	t.Run("should attribute panics to the originating package", func(t *testing.T) {
+		SetLogger(&mockLogger{})
+		defer SetLogger(&defaultLoggerImpl{})
+
+		pkg := packagePath + "/internal/userpanic"
+		before := PanicsByPackage()[pkg]
+		<-GoDone(func() { userpanic.Panic("attributed panic") })
+		GoErr(func() error { userpanic.Panic("attributed panic"); return nil }, func(error) {})
+
+		assert.Eventually(t, func() bool {
+			return PanicsByPackage()[pkg] == before+2
+		}, time.Second, time.Millisecond)
+	})
+
+	t.Run("should parse the package of the top user frame", func(t *testing.T) {
+		stack := []byte(`goroutine 7 [running]:
+runtime/debug.Stack()
+	/usr/local/go/src/runtime/debug/stack.go:26 +0x5e
+github.com/tinystack/tsafe.GoWithRecover.func2.1.1()
+	/src/tsafe/goroutine.go:178 +0x5f
+panic({0x5d2f40?, 0x6c1e30?})
+	/usr/local/go/src/runtime/panic.go:792 +0x132
+runtime.panicmem(...)
+	/usr/local/go/src/runtime/panic.go:262
+example.com/app/db.(*Conn).Query(0x0)
+	/src/app/db/conn.go:42 +0x1d
+main.main()
+	/src/app/main.go:10 +0x25
+`)
+		assert.Equal(t, "example.com/app/db", panicPackage(stack))
+		assert.Equal(t, "main", functionPackage("main.main()"))
+		assert.Equal(t, unknownPackage, panicPackage(nil))
+	})
+}
@@ -0,0 +1,39 @@
+package tsafe
+
+import "sync/atomic"
+
+// propagateToParent is 1 when GoWithParent hands panics to the parent handler
+var propagateToParent int32
+
+// SetPropagateToParent enables or disables forwarding panics of goroutines started with
+// GoWithParent to their parent handler. It is off by default, so only logging takes place
+// This function is thread-safe
+func SetPropagateToParent(on bool) {
+	var v int32
+	if on {
+		v = 1
+	}
+	atomic.StoreInt32(&propagateToParent, v)
+}
+
+// GoWithParent starts a goroutine with automatic panic recovery, modeling a nested recovery
+// scope: goroutines do not share the stack of the code that started them, so an enclosing
+// recover, e.g. a framework's per-request recovery, cannot see their panics, and parent is
+// the explicit handler standing in for it
+// A panic is logged like with Go and, while SetPropagateToParent is enabled, then passed to
+// parent. A panic inside parent is recovered and logged. A nil parent behaves like Go
+func GoWithParent(parent func(err any), goroutine func()) {
+	if parent == nil {
+		Go(goroutine)
+		return
+	}
+	GoWithRecover(goroutine, func(err any) {
+		logPanic(err)
+		if atomic.LoadInt32(&propagateToParent) == 0 {
+			return
+		}
+		if pe := runRecovered(func() { parent(err) }); pe != nil {
+			reportPanic(pe.Value, pe.Stack, nil)
+		}
+	})
+}
@@ -0,0 +1,55 @@
+package tsafe
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoWithParent(t *testing.T) {
+	defer SetPropagateToParent(false)
+
+	t.Run("should log and forward panics to the parent when enabled", func(t *testing.T) {
+		mock := &mockLogger{}
+		SetLogger(mock)
+		defer SetLogger(&defaultLoggerImpl{})
+		SetPropagateToParent(true)
+
+		forwarded := make(chan any, 1)
+		GoWithParent(func(err any) { forwarded <- err }, func() { panic("nested panic") })
+
+		select {
+		case err := <-forwarded:
+			assert.Equal(t, "nested panic", err)
+		case <-time.After(time.Second):
+			t.Fatal("panic was not forwarded to the parent")
+		}
+		assert.Equal(t, 1, mock.getCallCount())
+	})
+
+	t.Run("should only log when propagation is disabled", func(t *testing.T) {
+		mock := &mockLogger{}
+		SetLogger(mock)
+		defer SetLogger(&defaultLoggerImpl{})
+		SetPropagateToParent(false)
+
+		forwarded := make(chan any, 1)
+		GoWithParent(func(err any) { forwarded <- err }, func() { panic("nested panic") })
+
+		assert.Eventually(t, func() bool { return mock.getCallCount() == 1 }, time.Second, time.Millisecond)
+		waitForNoActiveGoroutines(t)
+		assert.Len(t, forwarded, 0)
+	})
+
+	t.Run("should recover a panicking parent", func(t *testing.T) {
+		mock := &mockLogger{}
+		SetLogger(mock)
+		defer SetLogger(&defaultLoggerImpl{})
+		SetPropagateToParent(true)
+
+		GoWithParent(func(err any) { panic("parent panic") }, func() { panic("nested panic") })
+		assert.Eventually(t, func() bool { return mock.getCallCount() == 2 }, time.Second, time.Millisecond)
+		assert.Equal(t, "parent panic", mock.getLastError())
+	})
+}
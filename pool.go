@@ -0,0 +1,237 @@
+package tsafe
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Pool runs submitted tasks in recovered goroutines with a bounded number running at once
+// Tasks submitted while the pool is saturated wait in a FIFO queue
+type Pool struct {
+	size    int
+	shared  *Limiter
+	breaker *breaker // nil unless WithBreaker is used
+	mu      sync.Mutex
+	changed *sync.Cond // signaled whenever a worker slot is released
+	queue   []*poolTask
+	pending int64 // queued tasks, mirrored for lock-free reads
+	running int64 // executing tasks, mirrored for lock-free reads
+	paused  bool
+	idle    chan struct{} // nil until IdleNotify is called
+}
+
+// NewPool creates a pool that runs at most size tasks concurrently
+// A non-positive size is treated as 1
+func NewPool(size int, opts ...PoolOption) *Pool {
+	if size <= 0 {
+		size = 1
+	}
+	p := &Pool{size: size}
+	p.changed = sync.NewCond(&p.mu)
+	for _, opt := range opts {
+		if opt != nil {
+			opt(p)
+		}
+	}
+	return p
+}
+
+// NewPoolWithLimiter creates a pool that runs at most local tasks concurrently and
+// additionally needs a slot from the shared limiter for every task
+// Sharing one Limiter between pools caps their combined concurrency
+func NewPoolWithLimiter(local int, shared *Limiter, opts ...PoolOption) *Pool {
+	p := NewPool(local, opts...)
+	p.shared = shared
+	return p
+}
+
+// poolTask is a unit of work queued on a Pool
+type poolTask struct {
+	fn      func()
+	onPanic func(err any, stack []byte)
+}
+
+// Submit schedules fn to run on the pool without blocking the caller
+// Panics in fn are recovered and logged with the configured logger
+// It returns ErrBreakerOpen, without scheduling fn, while the pool's breaker is open
+func (p *Pool) Submit(fn func()) error {
+	return p.SubmitRecover(fn, nil)
+}
+
+// SubmitRecover schedules fn like Submit but handles its panic with onPanic instead of the
+// pool's default logging, e.g. to re-enqueue the failed task. A nil onPanic applies the default
+// A panic inside onPanic is recovered and logged, and the worker slot is still released
+// Like Submit it returns ErrBreakerOpen while the pool's breaker is open
+func (p *Pool) SubmitRecover(fn func(), onPanic func(err any, stack []byte)) error {
+	if fn == nil {
+		return nil
+	}
+	if p.breaker != nil {
+		if err := p.breaker.allow(); err != nil {
+			return err
+		}
+	}
+	task := &poolTask{fn: fn, onPanic: onPanic}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.paused && int(p.running) < p.size {
+		atomic.AddInt64(&p.running, 1)
+		spawn(func() { p.worker(task) })
+		return nil
+	}
+	p.queue = append(p.queue, task)
+	atomic.AddInt64(&p.pending, 1)
+	return nil
+}
+
+// worker runs task and then keeps draining the queue until it is empty
+func (p *Pool) worker(task *poolTask) {
+	for task != nil {
+		p.execute(task)
+		task = p.next()
+	}
+}
+
+// execute runs a single task with panic recovery
+// The local slot is already held by the worker; the shared slot is always acquired
+// after it and released before it, so pools sharing a limiter cannot deadlock
+func (p *Pool) execute(task *poolTask) {
+	if p.shared != nil {
+		p.shared.acquire()
+		defer p.shared.release()
+	}
+	pe := runRecovered(task.fn)
+	if p.breaker != nil {
+		p.breaker.record(pe != nil)
+	}
+	if pe == nil {
+		return
+	}
+	if task.onPanic == nil {
+		reportPanic(pe.Value, pe.Stack, nil)
+		return
+	}
+	if handlerPanic := runRecovered(func() { task.onPanic(pe.Value, pe.Stack) }); handlerPanic != nil {
+		reportPanic(handlerPanic.Value, handlerPanic.Stack, nil)
+	}
+}
+
+// next pops the next queued task, or releases the worker slot if there is none or the pool is paused
+func (p *Pool) next() *poolTask {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.paused && len(p.queue) > 0 {
+		task := p.queue[0]
+		p.queue[0] = nil
+		p.queue = p.queue[1:]
+		atomic.AddInt64(&p.pending, -1)
+		return task
+	}
+	atomic.AddInt64(&p.running, -1)
+	p.changed.Broadcast()
+	if p.running == 0 && len(p.queue) == 0 && p.idle != nil {
+		select {
+		case p.idle <- struct{}{}:
+		default: // A signal is already pending
+		}
+	}
+	return nil
+}
+
+// RunSync executes fn inline in the calling goroutine, with panic recovery, as one of the pool's tasks
+// It waits for a free worker slot and holds it while fn runs, so it respects the pool's
+// concurrency limit and shows up in Running and WaitIdle. Since no goroutine is spawned,
+// it helps measure the cost of the pool's bookkeeping separately from goroutine creation
+// Queued tasks take precedence over RunSync for slots that free up, and it waits while the pool is paused
+func (p *Pool) RunSync(fn func()) {
+	if fn == nil {
+		return
+	}
+
+	p.mu.Lock()
+	for p.paused || int(p.running) >= p.size {
+		p.changed.Wait()
+	}
+	atomic.AddInt64(&p.running, 1)
+	p.mu.Unlock()
+
+	p.execute(&poolTask{fn: fn})
+
+	// Hand the slot over to a queued task, or release it
+	if next := p.next(); next != nil {
+		spawn(func() { p.worker(next) })
+	}
+}
+
+// Pending returns the number of submitted tasks waiting to start
+func (p *Pool) Pending() int {
+	return int(atomic.LoadInt64(&p.pending))
+}
+
+// Running returns the number of tasks currently executing
+func (p *Pool) Running() int {
+	return int(atomic.LoadInt64(&p.running))
+}
+
+// Pause stops the pool from starting tasks: submitted tasks are kept in the queue, while
+// tasks already running continue until they return. Pausing a paused pool is a no-op
+// This method is thread-safe
+func (p *Pool) Pause() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.paused = true
+}
+
+// Resume lets a paused pool start tasks again and drains the queued backlog up to the
+// pool's concurrency limit. Resuming a pool that is not paused is a no-op
+// This method is thread-safe
+func (p *Pool) Resume() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.paused {
+		return
+	}
+	p.paused = false
+	for int(p.running) < p.size && len(p.queue) > 0 {
+		task := p.queue[0]
+		p.queue[0] = nil
+		p.queue = p.queue[1:]
+		atomic.AddInt64(&p.pending, -1)
+		atomic.AddInt64(&p.running, 1)
+		spawn(func() { p.worker(task) })
+	}
+	// Wake RunSync callers waiting for the pool to resume
+	p.changed.Broadcast()
+}
+
+// Paused reports whether the pool is paused
+func (p *Pool) Paused() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.paused
+}
+
+// IdleNotify returns a channel signaled whenever the pool becomes idle, i.e. its last running
+// task finished and no task is pending, to start follow-up work once a batch is done
+// The channel has a buffer of one and signals coalesce: the pool never blocks on it, and
+// several transitions to idle before a receive are delivered as a single signal
+// Every call returns the same channel
+func (p *Pool) IdleNotify() <-chan struct{} {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.idle == nil {
+		p.idle = make(chan struct{}, 1)
+	}
+	return p.idle
+}
+
+// WaitIdle blocks until no tasks are running or pending
+// Tasks queued on a paused pool are pending, so WaitIdle waits for the pool to be resumed
+func (p *Pool) WaitIdle() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for p.running > 0 || len(p.queue) > 0 {
+		p.changed.Wait()
+	}
+}
@@ -0,0 +1,310 @@
+package tsafe
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPool(t *testing.T) {
+	t.Run("should run all submitted tasks", func(t *testing.T) {
+		pool := NewPool(4)
+		var count int32
+		for i := 0; i < 100; i++ {
+			pool.Submit(func() {
+				atomic.AddInt32(&count, 1)
+			})
+		}
+		pool.WaitIdle()
+		assert.Equal(t, int32(100), atomic.LoadInt32(&count))
+		assert.Equal(t, 0, pool.Running())
+		assert.Equal(t, 0, pool.Pending())
+	})
+
+	t.Run("should report running and pending tasks", func(t *testing.T) {
+		pool := NewPool(2)
+		release := make(chan struct{})
+		for i := 0; i < 5; i++ {
+			pool.Submit(func() {
+				<-release
+			})
+		}
+
+		assert.Equal(t, 2, pool.Running())
+		assert.Equal(t, 3, pool.Pending())
+
+		close(release)
+		pool.WaitIdle()
+		assert.Equal(t, 0, pool.Running())
+		assert.Equal(t, 0, pool.Pending())
+	})
+
+	t.Run("should recover panicking tasks and keep working", func(t *testing.T) {
+		mock := &mockLogger{}
+		originalLogger := getLogger()
+		SetLogger(mock)
+		defer SetLogger(originalLogger)
+
+		pool := NewPool(1)
+		var count int32
+		pool.Submit(func() { panic("pool panic") })
+		pool.Submit(func() { atomic.AddInt32(&count, 1) })
+		pool.WaitIdle()
+
+		assert.Equal(t, int32(1), atomic.LoadInt32(&count))
+		assert.Equal(t, 1, mock.getCallCount())
+		assert.Equal(t, "pool panic", mock.getLastError())
+	})
+
+	t.Run("should return immediately from WaitIdle when idle", func(t *testing.T) {
+		pool := NewPool(1)
+		done := make(chan struct{})
+		go func() {
+			pool.WaitIdle()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("WaitIdle blocked on an idle pool")
+		}
+	})
+
+	t.Run("should run tasks synchronously with RunSync", func(t *testing.T) {
+		pool := NewPool(1)
+		ran := false
+		pool.RunSync(func() {
+			ran = true
+			assert.Equal(t, 1, pool.Running())
+		})
+		assert.True(t, ran)
+		assert.Equal(t, 0, pool.Running())
+	})
+
+	t.Run("should respect the concurrency limit in RunSync", func(t *testing.T) {
+		pool := NewPool(1)
+		release := make(chan struct{})
+		pool.Submit(func() { <-release })
+
+		done := make(chan struct{})
+		go func() {
+			pool.RunSync(func() {})
+			close(done)
+		}()
+
+		select {
+		case <-done:
+			t.Fatal("RunSync did not wait for a free slot")
+		case <-time.After(20 * time.Millisecond):
+		}
+		close(release)
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("RunSync did not run after the slot was released")
+		}
+		pool.WaitIdle()
+	})
+
+	t.Run("should recover panics in RunSync", func(t *testing.T) {
+		mock := &mockLogger{}
+		originalLogger := getLogger()
+		SetLogger(mock)
+		defer SetLogger(originalLogger)
+
+		pool := NewPool(1)
+		assert.NotPanics(t, func() {
+			pool.RunSync(func() { panic("sync panic") })
+		})
+		assert.Equal(t, 1, mock.getCallCount())
+		assert.Equal(t, 0, pool.Running())
+	})
+
+	t.Run("should use the per-task panic handler", func(t *testing.T) {
+		mock := &mockLogger{}
+		originalLogger := getLogger()
+		SetLogger(mock)
+		defer SetLogger(originalLogger)
+
+		pool := NewPool(1)
+		handled := make(chan any, 1)
+		pool.SubmitRecover(func() {
+			panic("task panic")
+		}, func(err any, stack []byte) {
+			assert.NotEmpty(t, stack)
+			handled <- err
+		})
+		pool.WaitIdle()
+
+		assert.Equal(t, "task panic", <-handled)
+		assert.Equal(t, 0, mock.getCallCount())
+	})
+
+	t.Run("should fall back to logging without a panic handler", func(t *testing.T) {
+		mock := &mockLogger{}
+		originalLogger := getLogger()
+		SetLogger(mock)
+		defer SetLogger(originalLogger)
+
+		pool := NewPool(1)
+		pool.SubmitRecover(func() { panic("default handling") }, nil)
+		pool.WaitIdle()
+		assert.Equal(t, 1, mock.getCallCount())
+	})
+
+	t.Run("should release the slot when the panic handler panics", func(t *testing.T) {
+		mock := &mockLogger{}
+		originalLogger := getLogger()
+		SetLogger(mock)
+		defer SetLogger(originalLogger)
+
+		pool := NewPool(1)
+		var completed int32
+		pool.SubmitRecover(func() {
+			panic("task panic")
+		}, func(err any, stack []byte) {
+			panic("handler panic")
+		})
+		pool.Submit(func() { atomic.AddInt32(&completed, 1) })
+		pool.WaitIdle()
+
+		assert.Equal(t, int32(1), atomic.LoadInt32(&completed))
+		assert.Equal(t, "handler panic", mock.getLastError())
+		assert.Equal(t, 0, pool.Running())
+	})
+
+	t.Run("should ignore nil tasks", func(t *testing.T) {
+		pool := NewPool(1)
+		assert.NotPanics(t, func() {
+			pool.Submit(nil)
+		})
+		assert.Equal(t, 0, pool.Pending())
+	})
+}
+
+// Benchmark tests
+func BenchmarkPoolSubmit(b *testing.B) {
+	pool := NewPool(64)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pool.Submit(func() {
+			// Do minimal work
+		})
+	}
+	pool.WaitIdle()
+}
+
+func BenchmarkPoolRunSync(b *testing.B) {
+	pool := NewPool(64)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pool.RunSync(func() {
+			// Do minimal work
+		})
+	}
+}
+
+func TestPoolPause(t *testing.T) {
+	t.Run("should hold submitted tasks while paused", func(t *testing.T) {
+		pool := NewPool(2)
+		pool.Pause()
+		pool.Pause()
+		assert.True(t, pool.Paused())
+
+		var count int32
+		for i := 0; i < 5; i++ {
+			pool.Submit(func() { atomic.AddInt32(&count, 1) })
+		}
+		time.Sleep(10 * time.Millisecond)
+		assert.Equal(t, int32(0), atomic.LoadInt32(&count))
+		assert.Equal(t, 5, pool.Pending())
+
+		pool.Resume()
+		pool.Resume()
+		pool.WaitIdle()
+		assert.Equal(t, int32(5), atomic.LoadInt32(&count))
+		assert.False(t, pool.Paused())
+	})
+
+	t.Run("should let running tasks finish but start no new ones", func(t *testing.T) {
+		pool := NewPool(1)
+		release := make(chan struct{})
+		var count int32
+		pool.Submit(func() {
+			<-release
+			atomic.AddInt32(&count, 1)
+		})
+		pool.Submit(func() { atomic.AddInt32(&count, 1) })
+
+		pool.Pause()
+		close(release)
+		assert.Eventually(t, func() bool { return pool.Running() == 0 }, time.Second, time.Millisecond)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&count))
+		assert.Equal(t, 1, pool.Pending())
+
+		pool.Resume()
+		pool.WaitIdle()
+		assert.Equal(t, int32(2), atomic.LoadInt32(&count))
+	})
+
+	t.Run("should be safe under concurrent submissions", func(t *testing.T) {
+		pool := NewPool(4)
+		var count int32
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for i := 0; i < 200; i++ {
+				pool.Submit(func() { atomic.AddInt32(&count, 1) })
+			}
+		}()
+		for i := 0; i < 20; i++ {
+			pool.Pause()
+			pool.Resume()
+		}
+		<-done
+		pool.WaitIdle()
+		assert.Equal(t, int32(200), atomic.LoadInt32(&count))
+	})
+}
+
+func TestPoolIdleNotify(t *testing.T) {
+	t.Run("should signal when a batch finishes", func(t *testing.T) {
+		pool := NewPool(2)
+		idle := pool.IdleNotify()
+		assert.Equal(t, idle, pool.IdleNotify())
+
+		release := make(chan struct{})
+		for i := 0; i < 5; i++ {
+			pool.Submit(func() { <-release })
+		}
+		select {
+		case <-idle:
+			t.Fatal("signaled while busy")
+		default:
+		}
+
+		close(release)
+		select {
+		case <-idle:
+		case <-time.After(time.Second):
+			t.Fatal("idle transition was not signaled")
+		}
+		assert.Equal(t, 0, pool.Running())
+		assert.Equal(t, 0, pool.Pending())
+	})
+
+	t.Run("should coalesce signals without blocking the pool", func(t *testing.T) {
+		pool := NewPool(1)
+		idle := pool.IdleNotify()
+		for i := 0; i < 3; i++ {
+			pool.Submit(func() {})
+			pool.WaitIdle()
+		}
+
+		assert.Eventually(t, func() bool { return len(idle) == 1 }, time.Second, time.Millisecond)
+		<-idle
+		assert.Len(t, idle, 0)
+	})
+}
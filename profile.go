@@ -0,0 +1,110 @@
+package tsafe
+
+import (
+	"os"
+	"path/filepath"
+	"runtime/pprof"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// defaultProfileInterval is the minimum time between two profile dumps unless WithProfileInterval is given
+const defaultProfileInterval = time.Minute
+
+// profileConfig holds the settings used by SetProfileOnPanic
+type profileConfig struct {
+	dir      string
+	heap     bool
+	interval time.Duration
+}
+
+// ProfileOption configures the profile dumps enabled by SetProfileOnPanic
+type ProfileOption func(*profileConfig)
+
+// WithHeapProfile additionally writes a heap profile next to every goroutine profile
+func WithHeapProfile() ProfileOption {
+	return func(c *profileConfig) {
+		c.heap = true
+	}
+}
+
+// WithProfileInterval sets the minimum time between two profile dumps
+// Panics recovered within the interval after a dump do not write new files. The default is 1 minute
+func WithProfileInterval(d time.Duration) ProfileOption {
+	return func(c *profileConfig) {
+		if d < 0 {
+			d = 0
+		}
+		c.interval = d
+	}
+}
+
+// Thread-safe profile-on-panic configuration
+var (
+	profileCfg   *profileConfig
+	profileMutex sync.RWMutex
+	lastProfile  int64 // unix nanoseconds of the last dump
+)
+
+// SetProfileOnPanic makes every recovered panic write a goroutine profile with full stacks
+// to a timestamped file in dir, capturing the whole-program state at failure time
+// Dumps are rate-limited, by default to one per minute, so a panic storm cannot fill the disk
+// Profiles are written synchronously by the panicking goroutine on a best-effort basis;
+// write errors are ignored. Passing an empty dir disables profiling
+// This function is thread-safe
+func SetProfileOnPanic(dir string, opts ...ProfileOption) {
+	profileMutex.Lock()
+	defer profileMutex.Unlock()
+	if dir == "" {
+		profileCfg = nil
+		return
+	}
+	c := &profileConfig{dir: dir, interval: defaultProfileInterval}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(c)
+		}
+	}
+	profileCfg = c
+	atomic.StoreInt64(&lastProfile, 0)
+}
+
+// getProfileConfig returns the current profile configuration in a thread-safe manner
+func getProfileConfig() *profileConfig {
+	profileMutex.RLock()
+	defer profileMutex.RUnlock()
+	return profileCfg
+}
+
+// profilePanic writes the configured profiles unless one was written within the interval
+func profilePanic() {
+	c := getProfileConfig()
+	if c == nil {
+		return
+	}
+	now := time.Now()
+	last := atomic.LoadInt64(&lastProfile)
+	if last != 0 && now.Sub(time.Unix(0, last)) < c.interval {
+		return
+	}
+	if !atomic.CompareAndSwapInt64(&lastProfile, last, now.UnixNano()) {
+		return // another goroutine is writing a dump for this burst
+	}
+
+	stamp := now.Format("20060102T150405.000000000")
+	writeProfile(filepath.Join(c.dir, "goroutine-"+stamp+".pprof"), "goroutine", 2)
+	if c.heap {
+		writeProfile(filepath.Join(c.dir, "heap-"+stamp+".pprof"), "heap", 0)
+	}
+}
+
+// writeProfile writes the named runtime profile to path
+func writeProfile(path, name string, debug int) {
+	f, err := os.Create(path)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	_ = pprof.Lookup(name).WriteTo(f, debug)
+}
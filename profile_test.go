@@ -0,0 +1,58 @@
+package tsafe
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetProfileOnPanic(t *testing.T) {
+	SetLogger(&mockLogger{})
+	defer SetLogger(&defaultLoggerImpl{})
+
+	t.Run("should write a rate-limited goroutine profile", func(t *testing.T) {
+		dir := t.TempDir()
+		SetProfileOnPanic(dir, WithProfileInterval(time.Hour))
+		defer SetProfileOnPanic("")
+
+		for i := 0; i < 3; i++ {
+			<-GoDone(func() { panic("boom") })
+		}
+
+		files, err := filepath.Glob(filepath.Join(dir, "goroutine-*.pprof"))
+		assert.NoError(t, err)
+		assert.Len(t, files, 1)
+
+		data, err := os.ReadFile(files[0])
+		assert.NoError(t, err)
+		assert.True(t, strings.Contains(string(data), "goroutine "))
+	})
+
+	t.Run("should write a heap profile when requested", func(t *testing.T) {
+		dir := t.TempDir()
+		SetProfileOnPanic(dir, WithHeapProfile())
+		defer SetProfileOnPanic("")
+
+		<-GoDone(func() { panic("boom") })
+
+		files, err := filepath.Glob(filepath.Join(dir, "heap-*.pprof"))
+		assert.NoError(t, err)
+		assert.Len(t, files, 1)
+	})
+
+	t.Run("should not write profiles when disabled", func(t *testing.T) {
+		dir := t.TempDir()
+		SetProfileOnPanic(dir)
+		SetProfileOnPanic("")
+
+		<-GoDone(func() { panic("boom") })
+
+		entries, err := os.ReadDir(dir)
+		assert.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+}
@@ -0,0 +1,78 @@
+package tsafe
+
+import (
+	"sync"
+	"time"
+)
+
+// raceHarnessTimeout bounds how long RaceHarness waits for its goroutines
+const raceHarnessTimeout = 10 * time.Second
+
+// TestingT is the subset of testing.TB used by RaceHarness
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+// discardLogger is the logger used by RaceHarness when none are given
+type discardLogger struct{}
+
+// Print implements the Logger interface by discarding the panic
+func (discardLogger) Print(err, stack any) {}
+
+// RaceHarness exercises the global configuration and recovery paths concurrently and is
+// meant to be called from a test run under the race detector, e.g. with go test -race in CI
+// Every iteration swaps the configured logger while goroutines started with Go, GoWithFields
+// and GoDone panic, so a custom logger passed in loggers that is not safe for concurrent use
+// makes the race detector fail the test. Without loggers a discarding logger is used
+// It reports an error on t if the goroutines do not finish within 10 seconds, e.g. because a
+// logger deadlocks, and restores the logger configured before the call
+// It also documents the intended usage: configuration may change at any time while goroutines run
+func RaceHarness(t TestingT, iterations int, loggers ...Logger) {
+	t.Helper()
+	if len(loggers) == 0 {
+		loggers = []Logger{discardLogger{}}
+	}
+	original := getLogger()
+	defer SetLogger(original)
+
+	var wg sync.WaitGroup
+	for i := 0; i < iterations; i++ {
+		logger := loggers[i%len(loggers)]
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			SetLogger(logger)
+		}()
+		var once sync.Once
+		finish := func() { once.Do(wg.Done) }
+		// finish also runs when the function is skipped, e.g. dropped after shutdown
+		goWithRecover(func() {
+			panic("tsafe: race harness panic")
+		}, func(err any) {
+			logPanic(err)
+			finish()
+		}, finish)
+		GoWithFields(map[string]any{"iteration": i}, func() {
+			panic("tsafe: race harness panic")
+		})
+		done := GoDone(func() {
+			panic("tsafe: race harness panic")
+		})
+		go func() {
+			defer wg.Done()
+			<-done
+		}()
+	}
+
+	finished := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(finished)
+	}()
+	select {
+	case <-finished:
+	case <-time.After(raceHarnessTimeout):
+		t.Errorf("tsafe: race harness goroutines did not finish within %v", raceHarnessTimeout)
+	}
+}
@@ -0,0 +1,31 @@
+package tsafe
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRaceHarness(t *testing.T) {
+	t.Run("should run with the default logger", func(t *testing.T) {
+		original := getLogger()
+		RaceHarness(t, 50)
+		assert.Equal(t, original, getLogger())
+	})
+
+	t.Run("should exercise the given loggers", func(t *testing.T) {
+		original := getLogger()
+		RaceHarness(t, 50, &mockLogger{}, &mockFieldLogger{}, &mockLeveledLogger{})
+		assert.Equal(t, original, getLogger())
+	})
+
+	t.Run("should finish when spawns are dropped after shutdown", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		SetShutdownContext(ctx)
+		defer SetShutdownContext(nil)
+
+		RaceHarness(t, 10, &mockLogger{})
+	})
+}
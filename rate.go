@@ -0,0 +1,53 @@
+package tsafe
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// panicRateCapacity bounds how many recent panic timestamps are kept for PanicRate
+const panicRateCapacity = 4096
+
+// Ring buffer of the most recent panic timestamps in unix nanoseconds
+var (
+	panicTimes     [panicRateCapacity]int64
+	panicTimesNext uint64
+)
+
+// recordPanicTime stores the time of a recovered panic in the ring buffer
+func recordPanicTime() {
+	i := atomic.AddUint64(&panicTimesNext, 1) - 1
+	atomic.StoreInt64(&panicTimes[i%panicRateCapacity], time.Now().UnixNano())
+}
+
+// PanicRate returns the number of recovered panics per second over the trailing window
+// It is backed by a fixed-size ring buffer of the last 4096 panic timestamps, so memory stays
+// bounded; when more panics than that fall inside the window, the rate is computed over the
+// shorter span the buffer still covers. A non-positive window returns 0
+// This function is thread-safe
+func PanicRate(window time.Duration) float64 {
+	if window <= 0 {
+		return 0
+	}
+	now := time.Now().UnixNano()
+	since := now - int64(window)
+
+	count := 0
+	oldest := now
+	for i := range panicTimes {
+		t := atomic.LoadInt64(&panicTimes[i])
+		if t == 0 || t < since {
+			continue
+		}
+		count++
+		if t < oldest {
+			oldest = t
+		}
+	}
+
+	span := window
+	if count == panicRateCapacity && now > oldest {
+		span = time.Duration(now - oldest)
+	}
+	return float64(count) / span.Seconds()
+}
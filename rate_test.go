@@ -0,0 +1,29 @@
+package tsafe
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPanicRate(t *testing.T) {
+	t.Run("should count recent panics per second", func(t *testing.T) {
+		SetLogger(&mockLogger{})
+		defer SetLogger(&defaultLoggerImpl{})
+
+		time.Sleep(60 * time.Millisecond) // Let earlier panics leave the window
+		for i := 0; i < 5; i++ {
+			<-GoDone(func() { panic("rated panic") })
+		}
+		rate := PanicRate(50 * time.Millisecond)
+		assert.GreaterOrEqual(t, rate, float64(100)) // at least 5 panics in 0.05s
+
+		time.Sleep(60 * time.Millisecond)
+		assert.Equal(t, float64(0), PanicRate(50*time.Millisecond))
+	})
+
+	t.Run("should return 0 for a non-positive window", func(t *testing.T) {
+		assert.Equal(t, float64(0), PanicRate(0))
+	})
+}
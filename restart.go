@@ -0,0 +1,68 @@
+package tsafe
+
+// defaultMaxRestarts is the restart cap used by GoRestartable unless WithMaxRestarts is given
+const defaultMaxRestarts = 10
+
+// restartConfig holds the settings used by GoRestartable
+type restartConfig struct {
+	maxRestarts int
+}
+
+// RestartOption configures the restart behavior of GoRestartable
+type RestartOption func(*restartConfig)
+
+// WithMaxRestarts caps how many times in a row GoRestartable re-runs a panicking function
+// Values below 0 are treated as 0, which never restarts. The default is 10
+func WithMaxRestarts(n int) RestartOption {
+	return func(c *restartConfig) {
+		if n < 0 {
+			n = 0
+		}
+		c.maxRestarts = n
+	}
+}
+
+// GoRestartable starts a recovered goroutine that re-runs goroutine whenever it panics and
+// onPanic asks for a restart by returning true
+// This is a lightweight self-supervising goroutine: there is no backoff, and the goroutine
+// ends as soon as goroutine returns normally or onPanic returns false
+// To guard against infinite restart loops at most WithMaxRestarts restarts happen; once the
+// cap is reached the panic is logged with the configured logger and the goroutine ends even
+// if onPanic asked for another restart. A nil onPanic logs the panic and never restarts
+// A panic in onPanic is recovered and logged, and the goroutine ends without a restart
+func GoRestartable(goroutine func(), onPanic func(err any) (restart bool), opts ...RestartOption) {
+	if goroutine == nil {
+		return // Avoid creating goroutine for nil function
+	}
+	c := &restartConfig{maxRestarts: defaultMaxRestarts}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(c)
+		}
+	}
+
+	spawn(func() {
+		for restarts := 0; ; restarts++ {
+			pe := runRecovered(goroutine)
+			if pe == nil {
+				return
+			}
+			if onPanic == nil {
+				reportPanic(pe.Value, pe.Stack, nil)
+				return
+			}
+			restart := false
+			if handlerPanic := runRecovered(func() { restart = onPanic(pe.Value) }); handlerPanic != nil {
+				reportPanic(handlerPanic.Value, handlerPanic.Stack, nil)
+				return
+			}
+			if !restart {
+				return
+			}
+			if restarts >= c.maxRestarts {
+				reportPanic(pe.Value, pe.Stack, nil)
+				return
+			}
+		}
+	})
+}
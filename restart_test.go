@@ -0,0 +1,97 @@
+package tsafe
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoRestartable(t *testing.T) {
+	t.Run("should restart until the function succeeds", func(t *testing.T) {
+		var runs int32
+		done := make(chan struct{})
+		GoRestartable(func() {
+			if atomic.AddInt32(&runs, 1) < 3 {
+				panic("flaky")
+			}
+			close(done)
+		}, func(err any) bool {
+			assert.Equal(t, "flaky", err)
+			return true
+		})
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("goroutine was not restarted")
+		}
+		assert.Equal(t, int32(3), atomic.LoadInt32(&runs))
+	})
+
+	t.Run("should stop when onPanic declines a restart", func(t *testing.T) {
+		var runs int32
+		handled := make(chan struct{})
+		GoRestartable(func() {
+			atomic.AddInt32(&runs, 1)
+			panic("boom")
+		}, func(err any) bool {
+			close(handled)
+			return false
+		})
+
+		<-handled
+		time.Sleep(20 * time.Millisecond)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&runs))
+	})
+
+	t.Run("should cap consecutive restarts and log the last panic", func(t *testing.T) {
+		logger := &mockLogger{}
+		SetLogger(logger)
+		defer SetLogger(&defaultLoggerImpl{})
+
+		var runs int32
+		GoRestartable(func() {
+			atomic.AddInt32(&runs, 1)
+			panic("always")
+		}, func(err any) bool {
+			return true
+		}, WithMaxRestarts(2))
+
+		assert.Eventually(t, func() bool { return logger.getCallCount() == 1 }, time.Second, time.Millisecond)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&runs))
+	})
+
+	t.Run("should log and not restart with a nil onPanic", func(t *testing.T) {
+		logger := &mockLogger{}
+		SetLogger(logger)
+		defer SetLogger(&defaultLoggerImpl{})
+
+		var runs int32
+		GoRestartable(func() {
+			atomic.AddInt32(&runs, 1)
+			panic("boom")
+		}, nil)
+
+		assert.Eventually(t, func() bool { return logger.getCallCount() == 1 }, time.Second, time.Millisecond)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&runs))
+	})
+	t.Run("should log a panicking onPanic and not restart", func(t *testing.T) {
+		logger := &mockLogger{}
+		SetLogger(logger)
+		defer SetLogger(&defaultLoggerImpl{})
+
+		var runs int32
+		GoRestartable(func() {
+			atomic.AddInt32(&runs, 1)
+			panic("boom")
+		}, func(err any) bool {
+			panic("handler boom")
+		})
+
+		assert.Eventually(t, func() bool { return logger.getCallCount() == 1 }, time.Second, time.Millisecond)
+		assert.Equal(t, "handler boom", logger.getLastError())
+		assert.Equal(t, int32(1), atomic.LoadInt32(&runs))
+	})
+}
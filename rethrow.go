@@ -0,0 +1,21 @@
+package tsafe
+
+// rethrowFunc re-raises a recovered panic so it crashes the process; replaced in tests
+var rethrowFunc = func(err any) { panic(err) }
+
+// GoWithRethrowIf starts a goroutine whose panics are all logged with the configured logger,
+// but only those for which shouldRethrow returns true are re-raised to crash the process,
+// e.g. runtime.Error values indicating real bugs, while string panics used for control flow
+// are just logged. Before a rethrow, FlushAll waits up to five seconds for the logger and
+// buffered sinks to write their entries
+// A nil shouldRethrow never rethrows, which makes it behave like Go
+func GoWithRethrowIf(goroutine func(), shouldRethrow func(err any) bool) {
+	GoWithRecover(goroutine, func(err any) {
+		logPanic(err)
+		if shouldRethrow == nil || !shouldRethrow(err) {
+			return
+		}
+		flushBeforeCrash()
+		rethrowFunc(err)
+	})
+}
@@ -0,0 +1,79 @@
+package tsafe
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoWithRethrowIf(t *testing.T) {
+	isRuntimeError := func(err any) bool {
+		_, ok := err.(runtime.Error)
+		return ok
+	}
+
+	t.Run("should log and rethrow matching panics", func(t *testing.T) {
+		mock := &mockLogger{}
+		SetLogger(mock)
+		defer SetLogger(&defaultLoggerImpl{})
+
+		rethrown := make(chan any, 1)
+		originalRethrow := rethrowFunc
+		rethrowFunc = func(err any) { rethrown <- err }
+		defer func() { rethrowFunc = originalRethrow }()
+
+		GoWithRethrowIf(func() {
+			var m map[string]int
+			m["boom"] = 1
+		}, isRuntimeError)
+
+		select {
+		case err := <-rethrown:
+			assert.True(t, isRuntimeError(err))
+		case <-time.After(time.Second):
+			t.Fatal("panic was not rethrown")
+		}
+		assert.Equal(t, 1, mock.getCallCount())
+	})
+
+	t.Run("should flush sinks before rethrowing", func(t *testing.T) {
+		SetLogger(&mockLogger{})
+		defer SetLogger(&defaultLoggerImpl{})
+		flushed := make(chan struct{}, 1)
+		defer RegisterSink(flusherSink{flush: func() error {
+			flushed <- struct{}{}
+			return nil
+		}})()
+
+		rethrown := make(chan any, 1)
+		originalRethrow := rethrowFunc
+		rethrowFunc = func(err any) { rethrown <- err }
+		defer func() { rethrowFunc = originalRethrow }()
+
+		GoWithRethrowIf(func() { panic("fatal") }, func(any) bool { return true })
+		select {
+		case <-rethrown:
+		case <-time.After(time.Second):
+			t.Fatal("panic was not rethrown")
+		}
+		assert.Len(t, flushed, 1)
+	})
+
+	t.Run("should only log panics that do not match", func(t *testing.T) {
+		mock := &mockLogger{}
+		SetLogger(mock)
+		defer SetLogger(&defaultLoggerImpl{})
+
+		rethrown := make(chan any, 1)
+		originalRethrow := rethrowFunc
+		rethrowFunc = func(err any) { rethrown <- err }
+		defer func() { rethrowFunc = originalRethrow }()
+
+		GoWithRethrowIf(func() { panic("control flow") }, isRuntimeError)
+		assert.Eventually(t, func() bool { return mock.getCallCount() == 1 }, time.Second, time.Millisecond)
+		waitForNoActiveGoroutines(t)
+		assert.Len(t, rethrown, 0)
+	})
+}
@@ -0,0 +1,167 @@
+package tsafe
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+// retryConfig holds the settings used by GoRetry and GoRetryContext
+type retryConfig struct {
+	maxAttempts int
+	backoff     time.Duration
+	jitter      float64
+	retryIf     func(err any) bool
+}
+
+// RetryOption configures the retry behavior of GoRetry and GoRetryContext
+type RetryOption func(*retryConfig)
+
+// WithMaxAttempts sets the total number of attempts, including the first one
+// Values below 1 are treated as 1. The default is 3
+func WithMaxAttempts(n int) RetryOption {
+	return func(c *retryConfig) {
+		if n < 1 {
+			n = 1
+		}
+		c.maxAttempts = n
+	}
+}
+
+// WithBackoff sets the delay before the first retry; it doubles after every further attempt,
+// up to one hour. The default is 10ms
+func WithBackoff(d time.Duration) RetryOption {
+	return func(c *retryConfig) {
+		if d < 0 {
+			d = 0
+		}
+		c.backoff = d
+	}
+}
+
+// WithJitter adds a random extra delay of up to fraction*backoff to every retry
+// This spreads out retries of many goroutines failing at the same time
+func WithJitter(fraction float64) RetryOption {
+	return func(c *retryConfig) {
+		if fraction < 0 {
+			fraction = 0
+		}
+		c.jitter = fraction
+	}
+}
+
+// WithRetryIf restricts retries to panics for which pred returns true
+// With GoRetryContext it also receives returned errors
+// Non-matching failures fail immediately without consuming the retry budget
+func WithRetryIf(pred func(err any) bool) RetryOption {
+	return func(c *retryConfig) {
+		c.retryIf = pred
+	}
+}
+
+// newRetryConfig applies opts on top of the defaults
+func newRetryConfig(opts []RetryOption) *retryConfig {
+	c := &retryConfig{maxAttempts: 3, backoff: 10 * time.Millisecond}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(c)
+		}
+	}
+	return c
+}
+
+// shouldRetry reports whether a failed attempt may be retried
+func (c *retryConfig) shouldRetry(err any, attempt int) bool {
+	if attempt >= c.maxAttempts {
+		return false
+	}
+	return c.retryIf == nil || c.retryIf(err)
+}
+
+// maxRetryDelay caps the exponential backoff between retries, and separately the jitter added to it
+const maxRetryDelay = time.Hour
+
+// delay returns the wait before the given retry (1-based)
+func (c *retryConfig) delay(retry int) time.Duration {
+	d := c.backoff
+	if d > maxRetryDelay {
+		d = maxRetryDelay
+	}
+	// Doubling stops at the cap, so d cannot overflow however many retries there are
+	for i := 1; i < retry && d > 0 && d < maxRetryDelay; i++ {
+		d *= 2
+		if d > maxRetryDelay {
+			d = maxRetryDelay
+		}
+	}
+	if c.jitter > 0 && d > 0 {
+		extra := rand.Float64() * c.jitter * float64(d)
+		if extra > float64(maxRetryDelay) {
+			extra = float64(maxRetryDelay)
+		}
+		d += time.Duration(extra)
+	}
+	return d
+}
+
+// GoRetry starts a goroutine that re-runs goroutine when it panics
+// Retries are governed by the given options; once the budget is exhausted, or a panic
+// does not match the WithRetryIf predicate, the last panic is logged with the configured logger
+func GoRetry(goroutine func(), opts ...RetryOption) {
+	if goroutine == nil {
+		return // Avoid creating goroutine for nil function
+	}
+
+	cfg := newRetryConfig(opts)
+	spawn(func() {
+		for attempt := 1; ; attempt++ {
+			pe := runRecovered(goroutine)
+			if pe == nil {
+				return
+			}
+			if !cfg.shouldRetry(pe.Value, attempt) {
+				reportPanic(pe.Value, pe.Stack, nil)
+				return
+			}
+			time.Sleep(cfg.delay(attempt))
+		}
+	})
+}
+
+// GoRetryContext runs fn with panic recovery, retrying it with backoff while it returns an
+// error or panics, and returns nil as soon as an attempt succeeds
+// Each attempt receives ctx. Once ctx is done no further attempt is started and ctx.Err() is
+// returned; the backoff wait is interrupted by the cancellation. When the budget is exhausted,
+// or a failure does not match the WithRetryIf predicate, the last error is returned, with
+// panics converted to *PanicError
+// Attempts run in the calling goroutine, which blocks until GoRetryContext returns
+func GoRetryContext(ctx context.Context, fn func(ctx context.Context) error, opts ...RetryOption) error {
+	if fn == nil {
+		return nil
+	}
+
+	cfg := newRetryConfig(opts)
+	for attempt := 1; ; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		var err error
+		if pe := runRecovered(func() { err = fn(ctx) }); pe != nil {
+			err = pe
+		}
+		if err == nil {
+			return nil
+		}
+		// The predicate sees panic values like GoRetry does, and errors as returned
+		var value any = err
+		if pe, ok := err.(*PanicError); ok {
+			value = pe.Value
+		}
+		if !cfg.shouldRetry(value, attempt) {
+			return err
+		}
+		if !sleepContext(ctx, cfg.delay(attempt)) {
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package tsafe
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoRetry(t *testing.T) {
+	t.Run("should retry until success", func(t *testing.T) {
+		var attempts int32
+		done := make(chan struct{})
+		GoRetry(func() {
+			if atomic.AddInt32(&attempts, 1) < 3 {
+				panic("transient")
+			}
+			close(done)
+		}, WithMaxAttempts(5), WithBackoff(time.Millisecond))
+
+		select {
+		case <-done:
+			assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+		case <-time.After(time.Second):
+			t.Fatal("goroutine did not succeed")
+		}
+	})
+
+	t.Run("should log the last panic when attempts are exhausted", func(t *testing.T) {
+		mock := &mockLogger{}
+		originalLogger := getLogger()
+		SetLogger(mock)
+		defer SetLogger(originalLogger)
+
+		var attempts int32
+		GoRetry(func() {
+			atomic.AddInt32(&attempts, 1)
+			panic("always fails")
+		}, WithMaxAttempts(3), WithBackoff(time.Millisecond), WithJitter(0.5))
+
+		assert.Eventually(t, func() bool {
+			return mock.getCallCount() == 1
+		}, time.Second, time.Millisecond)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+		assert.Equal(t, "always fails", mock.getLastError())
+	})
+
+	t.Run("should not retry panics rejected by the predicate", func(t *testing.T) {
+		mock := &mockLogger{}
+		originalLogger := getLogger()
+		SetLogger(mock)
+		defer SetLogger(originalLogger)
+
+		var attempts int32
+		GoRetry(func() {
+			atomic.AddInt32(&attempts, 1)
+			panic("programming error")
+		}, WithMaxAttempts(5), WithBackoff(time.Millisecond), WithRetryIf(func(err any) bool {
+			return err == "transient"
+		}))
+
+		assert.Eventually(t, func() bool {
+			return mock.getCallCount() == 1
+		}, time.Second, time.Millisecond)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+		assert.Equal(t, "programming error", mock.getLastError())
+	})
+
+	t.Run("should handle nil function gracefully", func(t *testing.T) {
+		assert.NotPanics(t, func() {
+			GoRetry(nil)
+		})
+	})
+}
+
+func TestGoRetryContext(t *testing.T) {
+	t.Run("should retry errors and panics until an attempt succeeds", func(t *testing.T) {
+		var attempts int32
+		err := GoRetryContext(context.Background(), func(ctx context.Context) error {
+			switch atomic.AddInt32(&attempts, 1) {
+			case 1:
+				return errors.New("transient")
+			case 2:
+				panic("flaky")
+			}
+			return nil
+		}, WithMaxAttempts(5), WithBackoff(time.Millisecond))
+
+		assert.NoError(t, err)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+	})
+
+	t.Run("should return the last failure once the budget is exhausted", func(t *testing.T) {
+		err := GoRetryContext(context.Background(), func(ctx context.Context) error {
+			panic("always fails")
+		}, WithMaxAttempts(3), WithBackoff(time.Millisecond))
+
+		var pe *PanicError
+		if assert.True(t, errors.As(err, &pe)) {
+			assert.Equal(t, "always fails", pe.Value)
+		}
+	})
+
+	t.Run("should interrupt the backoff when the context is canceled", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		var attempts int32
+		start := time.Now()
+		err := GoRetryContext(ctx, func(ctx context.Context) error {
+			atomic.AddInt32(&attempts, 1)
+			return errors.New("down")
+		}, WithMaxAttempts(10), WithBackoff(time.Hour))
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(start), time.Second)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+	})
+
+	t.Run("should not start an attempt with a done context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := GoRetryContext(ctx, func(ctx context.Context) error {
+			t.Error("should not run")
+			return nil
+		})
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("should stop on failures rejected by the predicate", func(t *testing.T) {
+		permanent := errors.New("permanent")
+		var attempts int32
+		err := GoRetryContext(context.Background(), func(ctx context.Context) error {
+			atomic.AddInt32(&attempts, 1)
+			return permanent
+		}, WithMaxAttempts(5), WithRetryIf(func(err any) bool { return err != permanent }))
+
+		assert.Equal(t, permanent, err)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+	})
+}
+
+func TestRetryDelay(t *testing.T) {
+	t.Run("should double the backoff after every retry", func(t *testing.T) {
+		c := newRetryConfig([]RetryOption{WithBackoff(10 * time.Millisecond)})
+		assert.Equal(t, 10*time.Millisecond, c.delay(1))
+		assert.Equal(t, 20*time.Millisecond, c.delay(2))
+		assert.Equal(t, 40*time.Millisecond, c.delay(3))
+	})
+
+	t.Run("should cap large backoffs instead of overflowing", func(t *testing.T) {
+		c := newRetryConfig([]RetryOption{WithBackoff(10 * time.Second), WithJitter(1e12)})
+		for _, retry := range []int{1, 31, 40, 1000} {
+			d := c.delay(retry)
+			assert.Greater(t, d, time.Duration(0))
+			assert.LessOrEqual(t, d, 2*maxRetryDelay)
+		}
+		assert.Equal(t, maxRetryDelay, newRetryConfig([]RetryOption{WithBackoff(10 * time.Second)}).delay(40))
+	})
+}
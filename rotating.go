@@ -0,0 +1,138 @@
+package tsafe
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RotatingFileLogger is a Logger writing panics to a file that is rotated once it reaches a
+// maximum size, keeping a bounded number of older files next to it as path.1, path.2, and so on
+// It implements Logger, FieldLogger and Flusher, and is safe for concurrent use
+type RotatingFileLogger struct {
+	path       string
+	maxBytes   int64
+	maxBackups int
+	mu         sync.Mutex
+	file       *os.File
+	size       int64
+}
+
+// NewRotatingFileLogger opens or creates the log file at path, appending to it
+// The file is rotated before a write would grow it past maxSizeMB megabytes, and at most
+// maxBackups rotated files are kept; the oldest is removed. A non-positive maxSizeMB is
+// treated as 1 and a negative maxBackups as 0, where rotation simply starts a new file
+func NewRotatingFileLogger(path string, maxSizeMB int, maxBackups int) (*RotatingFileLogger, error) {
+	if maxSizeMB <= 0 {
+		maxSizeMB = 1
+	}
+	if maxBackups < 0 {
+		maxBackups = 0
+	}
+	l := &RotatingFileLogger{
+		path:       path,
+		maxBytes:   int64(maxSizeMB) << 20,
+		maxBackups: maxBackups,
+	}
+	if err := l.open(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Print implements the Logger interface for RotatingFileLogger
+func (l *RotatingFileLogger) Print(err, stack any) {
+	l.write(fmt.Sprintf("[%s] ERROR: %v\nSTACK:\n%s\n---\n", logTimestamp(), err, stack))
+}
+
+// PrintFields implements the FieldLogger interface for RotatingFileLogger
+// Fields are appended to the error line sorted by key
+func (l *RotatingFileLogger) PrintFields(err, stack any, fields map[string]any) {
+	l.write(fmt.Sprintf("[%s] ERROR: %v %s\nSTACK:\n%s\n---\n", logTimestamp(), err, formatFields(fields), stack))
+}
+
+// Flush implements the Flusher interface by syncing the current file to disk
+func (l *RotatingFileLogger) Flush() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file == nil {
+		return nil
+	}
+	return l.file.Sync()
+}
+
+// Close closes the current file; entries logged afterwards are dropped
+func (l *RotatingFileLogger) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file == nil {
+		return nil
+	}
+	err := l.file.Close()
+	l.file = nil
+	return err
+}
+
+// logTimestamp formats the current time for a log entry
+func logTimestamp() string {
+	return time.Now().Format("2006-01-02 15:04:05")
+}
+
+// write appends an entry, rotating first if it would exceed the maximum size
+// Write errors are dropped, since the logger is the last stop of the recovery path
+func (l *RotatingFileLogger) write(entry string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file == nil {
+		return
+	}
+	if l.size > 0 && l.size+int64(len(entry)) > l.maxBytes {
+		if err := l.rotate(); err != nil {
+			return
+		}
+	}
+	n, _ := l.file.WriteString(entry)
+	l.size += int64(n)
+}
+
+// open opens the log file for appending and records its current size; l.mu must be held
+func (l *RotatingFileLogger) open() error {
+	file, err := os.OpenFile(l.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	l.file = file
+	l.size = info.Size()
+	return nil
+}
+
+// rotate shifts the backups, moves the current file to path.1 and opens a new file; l.mu must be held
+func (l *RotatingFileLogger) rotate() error {
+	if err := l.file.Close(); err != nil {
+		return err
+	}
+	l.file = nil
+
+	if l.maxBackups == 0 {
+		_ = os.Remove(l.path)
+	} else {
+		_ = os.Remove(l.backupName(l.maxBackups))
+		for i := l.maxBackups - 1; i >= 1; i-- {
+			_ = os.Rename(l.backupName(i), l.backupName(i+1))
+		}
+		_ = os.Rename(l.path, l.backupName(1))
+	}
+	return l.open()
+}
+
+// backupName returns the path of the i-th most recent rotated file
+func (l *RotatingFileLogger) backupName(i int) string {
+	return l.path + "." + strconv.Itoa(i)
+}
@@ -0,0 +1,94 @@
+package tsafe
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRotatingFileLogger(t *testing.T) {
+	t.Run("should append entries to the file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "panics.log")
+		l, err := NewRotatingFileLogger(path, 1, 2)
+		assert.NoError(t, err)
+		defer l.Close()
+
+		l.Print("boom", "stack")
+		l.PrintFields("bang", "stack", map[string]any{"task": "sync"})
+		assert.NoError(t, l.Flush())
+
+		data, err := os.ReadFile(path)
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), "ERROR: boom")
+		assert.Contains(t, string(data), "ERROR: bang task=sync")
+	})
+
+	t.Run("should rotate and keep at most maxBackups files", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "panics.log")
+		l, err := NewRotatingFileLogger(path, 1, 2)
+		assert.NoError(t, err)
+		defer l.Close()
+		l.maxBytes = 100
+
+		for i := 0; i < 10; i++ {
+			l.Print(strings.Repeat("x", 40), "stack")
+		}
+
+		for _, name := range []string{path, path + ".1", path + ".2"} {
+			info, err := os.Stat(name)
+			assert.NoError(t, err)
+			assert.LessOrEqual(t, info.Size(), int64(100))
+		}
+		_, err = os.Stat(path + ".3")
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("should start a new file without backups", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "panics.log")
+		l, err := NewRotatingFileLogger(path, 1, 0)
+		assert.NoError(t, err)
+		defer l.Close()
+		l.maxBytes = 100
+
+		for i := 0; i < 5; i++ {
+			l.Print(strings.Repeat("x", 40), "stack")
+		}
+		entries, err := os.ReadDir(dir)
+		assert.NoError(t, err)
+		assert.Len(t, entries, 1)
+	})
+
+	t.Run("should be safe for concurrent use", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "panics.log")
+		l, err := NewRotatingFileLogger(path, 1, 3)
+		assert.NoError(t, err)
+		defer l.Close()
+		l.maxBytes = 1000
+
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 10; j++ {
+					l.Print("concurrent", "stack")
+				}
+			}()
+		}
+		wg.Wait()
+
+		data, err := os.ReadFile(path)
+		assert.NoError(t, err)
+		assert.True(t, strings.HasSuffix(string(data), "---\n"))
+	})
+
+	t.Run("should fail for an unwritable path", func(t *testing.T) {
+		_, err := NewRotatingFileLogger(filepath.Join(t.TempDir(), "missing", "panics.log"), 1, 1)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,77 @@
+package tsafe
+
+import (
+	"context"
+	"sync"
+)
+
+// runnerTask is a named task registered with a Runner
+type runnerTask struct {
+	name string
+	fn   func() error
+}
+
+// Runner runs a fixed set of named tasks concurrently and reports the outcome of each one
+// It suits application bootstrap where many subsystems initialize in parallel
+// The zero value is ready to use
+type Runner struct {
+	mu    sync.Mutex
+	tasks []runnerTask
+}
+
+// Add registers a named task; adding a name twice replaces the earlier task
+func (r *Runner) Add(name string, fn func() error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, t := range r.tasks {
+		if t.name == name {
+			r.tasks[i].fn = fn
+			return
+		}
+	}
+	r.tasks = append(r.tasks, runnerTask{name: name, fn: fn})
+}
+
+// Run starts all registered tasks concurrently and waits for them to finish
+// The result maps every task name to its error: nil on success, the returned error,
+// or a *PanicError if the task panicked. If ctx ends first, Run returns immediately and
+// tasks that have not finished report ctx.Err(); they keep running in the background
+func (r *Runner) Run(ctx context.Context) map[string]error {
+	r.mu.Lock()
+	tasks := append([]runnerTask(nil), r.tasks...)
+	r.mu.Unlock()
+
+	type outcome struct {
+		name string
+		err  error
+	}
+	outcomes := make(chan outcome, len(tasks))
+	for _, t := range tasks {
+		t := t
+		spawn(func() {
+			var err error
+			if t.fn != nil {
+				if pe := runRecovered(func() { err = t.fn() }); pe != nil {
+					err = pe
+				}
+			}
+			outcomes <- outcome{name: t.name, err: err}
+		})
+	}
+
+	results := make(map[string]error, len(tasks))
+	for range tasks {
+		select {
+		case o := <-outcomes:
+			results[o.name] = o.err
+		case <-ctx.Done():
+			for _, t := range tasks {
+				if _, ok := results[t.name]; !ok {
+					results[t.name] = ctx.Err()
+				}
+			}
+			return results
+		}
+	}
+	return results
+}
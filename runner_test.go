@@ -0,0 +1,79 @@
+package tsafe
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunner(t *testing.T) {
+	t.Run("should report the outcome of every task", func(t *testing.T) {
+		expected := errors.New("cache unavailable")
+		var r Runner
+		r.Add("db", func() error { return nil })
+		r.Add("cache", func() error { return expected })
+		r.Add("queue", func() error { panic("queue panic") })
+
+		results := r.Run(context.Background())
+		assert.Len(t, results, 3)
+		assert.NoError(t, results["db"])
+		assert.Equal(t, expected, results["cache"])
+
+		var pe *PanicError
+		if assert.True(t, errors.As(results["queue"], &pe)) {
+			assert.Equal(t, "queue panic", pe.Value)
+		}
+	})
+
+	t.Run("should run tasks concurrently", func(t *testing.T) {
+		var r Runner
+		barrier := make(chan struct{})
+		r.Add("first", func() error {
+			<-barrier
+			return nil
+		})
+		r.Add("second", func() error {
+			close(barrier)
+			return nil
+		})
+
+		results := r.Run(context.Background())
+		assert.NoError(t, results["first"])
+		assert.NoError(t, results["second"])
+	})
+
+	t.Run("should replace tasks added twice", func(t *testing.T) {
+		var r Runner
+		r.Add("task", func() error { return errors.New("old") })
+		r.Add("task", func() error { return nil })
+
+		results := r.Run(context.Background())
+		assert.Len(t, results, 1)
+		assert.NoError(t, results["task"])
+	})
+
+	t.Run("should report context errors for unfinished tasks", func(t *testing.T) {
+		var r Runner
+		release := make(chan struct{})
+		defer close(release)
+		r.Add("fast", func() error { return nil })
+		r.Add("slow", func() error {
+			<-release
+			return nil
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		results := r.Run(ctx)
+		assert.NoError(t, results["fast"])
+		assert.ErrorIs(t, results["slow"], context.DeadlineExceeded)
+	})
+
+	t.Run("should return an empty map without tasks", func(t *testing.T) {
+		var r Runner
+		assert.Empty(t, r.Run(context.Background()))
+	})
+}
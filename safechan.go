@@ -0,0 +1,47 @@
+package tsafe
+
+import "runtime"
+
+// SafeClose closes ch unless it is already closed
+// It returns true if this call closed the channel and false if it was closed before
+// The double close is detected by recovering the runtime's "close of closed channel" panic;
+// any other panic, such as closing a nil channel, is re-raised
+func SafeClose[T any](ch chan T) (closed bool) {
+	defer func() {
+		if err := recover(); err != nil {
+			if !isRuntimeError(err, "close of closed channel") {
+				panic(err)
+			}
+			closed = false
+		}
+	}()
+	close(ch)
+	return true
+}
+
+// SafeSend sends v on ch, blocking like a plain send, unless ch is closed
+// It returns true if the value was sent and false if the channel was closed. A close racing
+// with the send is still a data race that the race detector reports, so senders and the
+// closer must be ordered by other means
+// Go offers no way to tell a closed channel apart without trying, so the closed case is
+// detected by recovering the runtime's "send on closed channel" panic; only that exact
+// runtime error is recovered and anything else is re-raised. Like a plain send, sending on a
+// nil channel blocks forever
+func SafeSend[T any](ch chan T, v T) (ok bool) {
+	defer func() {
+		if err := recover(); err != nil {
+			if !isRuntimeError(err, "send on closed channel") {
+				panic(err)
+			}
+			ok = false
+		}
+	}()
+	ch <- v
+	return true
+}
+
+// isRuntimeError reports whether err is a runtime error with the given message
+func isRuntimeError(err any, message string) bool {
+	re, ok := err.(runtime.Error)
+	return ok && re.Error() == message
+}
@@ -0,0 +1,37 @@
+package tsafe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSafeClose(t *testing.T) {
+	t.Run("should close an open channel once", func(t *testing.T) {
+		ch := make(chan int)
+		assert.True(t, SafeClose(ch))
+		assert.False(t, SafeClose(ch))
+
+		_, open := <-ch
+		assert.False(t, open)
+	})
+
+	t.Run("should re-raise other panics", func(t *testing.T) {
+		var ch chan int
+		assert.Panics(t, func() { SafeClose(ch) })
+	})
+}
+
+func TestSafeSend(t *testing.T) {
+	t.Run("should send on an open channel", func(t *testing.T) {
+		ch := make(chan int, 1)
+		assert.True(t, SafeSend(ch, 7))
+		assert.Equal(t, 7, <-ch)
+	})
+
+	t.Run("should report a closed channel", func(t *testing.T) {
+		ch := make(chan int, 1)
+		close(ch)
+		assert.False(t, SafeSend(ch, 7))
+	})
+}
@@ -0,0 +1,43 @@
+package tsafe
+
+import "sync"
+
+// rangeConfig holds the settings used by SafeRange
+type rangeConfig struct {
+	stopOnPanic bool
+}
+
+// RangeOption configures SafeRange
+type RangeOption func(*rangeConfig)
+
+// StopOnPanic makes SafeRange stop iterating after the first panicking callback
+// instead of continuing with the next entry
+func StopOnPanic() RangeOption {
+	return func(c *rangeConfig) {
+		c.stopOnPanic = true
+	}
+}
+
+// SafeRange calls fn for each key and value in m like m.Range, recovering panics in fn
+// A panicking invocation is logged with the configured logger and iteration continues with
+// the next entry, unless StopOnPanic is given. Returning false from fn stops iteration as usual
+func SafeRange(m *sync.Map, fn func(key, value any) bool, opts ...RangeOption) {
+	if m == nil || fn == nil {
+		return
+	}
+	c := &rangeConfig{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(c)
+		}
+	}
+
+	m.Range(func(key, value any) bool {
+		more := false
+		if pe := runRecovered(func() { more = fn(key, value) }); pe != nil {
+			reportPanic(pe.Value, pe.Stack, nil)
+			return !c.stopOnPanic
+		}
+		return more
+	})
+}
@@ -0,0 +1,66 @@
+package tsafe
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSafeRange(t *testing.T) {
+	newMap := func() *sync.Map {
+		m := &sync.Map{}
+		for i := 0; i < 5; i++ {
+			m.Store(i, i*10)
+		}
+		return m
+	}
+
+	t.Run("should visit every entry", func(t *testing.T) {
+		sum := 0
+		SafeRange(newMap(), func(key, value any) bool {
+			sum += value.(int)
+			return true
+		})
+		assert.Equal(t, 100, sum)
+	})
+
+	t.Run("should log a panicking callback and continue", func(t *testing.T) {
+		mock := &mockLogger{}
+		SetLogger(mock)
+		defer SetLogger(&defaultLoggerImpl{})
+
+		visited := 0
+		SafeRange(newMap(), func(key, value any) bool {
+			visited++
+			if key.(int) == 2 {
+				panic("bad entry")
+			}
+			return true
+		})
+		assert.Equal(t, 5, visited)
+		assert.Equal(t, 1, mock.getCallCount())
+		assert.Equal(t, "bad entry", mock.getLastError())
+	})
+
+	t.Run("should stop after a panic with StopOnPanic", func(t *testing.T) {
+		SetLogger(&mockLogger{})
+		defer SetLogger(&defaultLoggerImpl{})
+
+		visited := 0
+		SafeRange(newMap(), func(key, value any) bool {
+			visited++
+			panic("bad entry")
+		}, StopOnPanic())
+		assert.Equal(t, 1, visited)
+	})
+
+	t.Run("should stop when the callback returns false", func(t *testing.T) {
+		visited := 0
+		SafeRange(newMap(), func(key, value any) bool {
+			visited++
+			return false
+		})
+		assert.Equal(t, 1, visited)
+	})
+}
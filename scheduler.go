@@ -0,0 +1,37 @@
+package tsafe
+
+import "sync"
+
+// defaultScheduler runs every function in a new goroutine
+func defaultScheduler(fn func()) {
+	go fn()
+}
+
+// Thread-safe scheduler management
+var (
+	scheduler      = defaultScheduler
+	schedulerMutex sync.RWMutex
+)
+
+// SetScheduler replaces how tsafe starts goroutines, e.g. to make concurrency tests reproducible
+// The scheduler receives every function tsafe would otherwise run with go fn(), already
+// wrapped with panic recovery and bookkeeping. A test scheduler may capture the functions
+// and run them later in a chosen order, or run them inline. Functions captured but not yet
+// run count as active goroutines, including towards SetMaxGlobalGoroutines
+// Passing nil restores the default scheduler, which just does go fn()
+// This function is thread-safe
+func SetScheduler(s func(fn func())) {
+	if s == nil {
+		s = defaultScheduler
+	}
+	schedulerMutex.Lock()
+	defer schedulerMutex.Unlock()
+	scheduler = s
+}
+
+// getScheduler returns the current scheduler in a thread-safe manner
+func getScheduler() func(fn func()) {
+	schedulerMutex.RLock()
+	defer schedulerMutex.RUnlock()
+	return scheduler
+}
@@ -0,0 +1,83 @@
+package tsafe
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// captureScheduler records scheduled functions so tests can run them deterministically
+type captureScheduler struct {
+	mu      sync.Mutex
+	pending []func()
+}
+
+func (c *captureScheduler) schedule(fn func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pending = append(c.pending, fn)
+}
+
+// drain runs the captured functions in order, including ones scheduled while draining
+func (c *captureScheduler) drain() {
+	for {
+		c.mu.Lock()
+		if len(c.pending) == 0 {
+			c.mu.Unlock()
+			return
+		}
+		fn := c.pending[0]
+		c.pending = c.pending[1:]
+		c.mu.Unlock()
+		fn()
+	}
+}
+
+func TestSetScheduler(t *testing.T) {
+	t.Run("should hand goroutines to the custom scheduler", func(t *testing.T) {
+		capture := &captureScheduler{}
+		SetScheduler(capture.schedule)
+		defer SetScheduler(nil)
+
+		var order []int
+		Go(func() {
+			order = append(order, 1)
+			Go(func() { order = append(order, 3) })
+		})
+		Go(func() { order = append(order, 2) })
+		assert.Empty(t, order)
+
+		capture.drain()
+		assert.Equal(t, []int{1, 2, 3}, order)
+	})
+
+	t.Run("should recover panics of captured functions", func(t *testing.T) {
+		logger := &mockLogger{}
+		SetLogger(logger)
+		defer SetLogger(&defaultLoggerImpl{})
+
+		capture := &captureScheduler{}
+		SetScheduler(capture.schedule)
+		defer SetScheduler(nil)
+
+		Go(func() { panic("scheduled panic") })
+		assert.NotPanics(t, capture.drain)
+		assert.Equal(t, 1, logger.getCallCount())
+		assert.Equal(t, "scheduled panic", logger.getLastError())
+	})
+
+	t.Run("should allow running functions inline under the global limit", func(t *testing.T) {
+		waitForNoActiveGoroutines(t)
+		SetScheduler(func(fn func()) { fn() })
+		defer SetScheduler(nil)
+		SetMaxGlobalGoroutines(2)
+		defer SetMaxGlobalGoroutines(0)
+
+		ran := false
+		Go(func() {
+			Go(func() { ran = true })
+		})
+		assert.True(t, ran)
+	})
+}
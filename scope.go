@@ -0,0 +1,129 @@
+//go:build go1.20
+
+package tsafe
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ScopeOption configures a Scope
+type ScopeOption func(*Scope)
+
+// WithPanicPropagation makes a panic in any task of the scope or its child scopes cancel
+// every ancestor scope up to the root with the *PanicError as the cancellation cause
+// The first panic thereby cancels all sibling tasks, can be read with context.Cause from any
+// scope context, and is returned by the root's Wait with the panic value preserved
+func WithPanicPropagation() ScopeOption {
+	return func(s *Scope) {
+		s.propagate = true
+	}
+}
+
+// Scope runs tasks in recovered goroutines bound to a shared context, for structured concurrency
+// The first task failure, an error or a panic converted to *PanicError, cancels the scope's
+// context with that failure as the cause and is returned by Wait
+// Scopes form a tree through Child: canceling a scope cancels its children, and a scope's
+// Wait also waits for the tasks of its children
+// Scope only exists with Go 1.20 or newer, see NewScope
+type Scope struct {
+	ctx       context.Context
+	cancel    context.CancelCauseFunc
+	parent    *Scope
+	propagate bool
+	wg        sync.WaitGroup
+	errOnce   sync.Once
+	err       error
+}
+
+// NewScope creates a root scope whose context is derived from ctx
+// This requires Go 1.20 or newer, which introduced context cancellation causes
+func NewScope(ctx context.Context, opts ...ScopeOption) *Scope {
+	s := &Scope{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(s)
+		}
+	}
+	s.ctx, s.cancel = context.WithCancelCause(ctx)
+	return s
+}
+
+// Child creates a scope whose context is derived from s and which inherits its options
+func (s *Scope) Child() *Scope {
+	child := &Scope{parent: s, propagate: s.propagate}
+	child.ctx, child.cancel = context.WithCancelCause(s.ctx)
+	return child
+}
+
+// Context returns the scope's context, canceled on the first failure or once Wait returns
+func (s *Scope) Context() context.Context {
+	return s.ctx
+}
+
+// Go starts fn in a recovered goroutine that belongs to the scope and receives its context
+func (s *Scope) Go(fn func(ctx context.Context) error) {
+	if fn == nil {
+		return // Avoid creating goroutine for nil function
+	}
+	for p := s; p != nil; p = p.parent {
+		p.wg.Add(1)
+	}
+
+	spawn(func() {
+		defer func() {
+			for p := s; p != nil; p = p.parent {
+				p.wg.Done()
+			}
+		}()
+		var err error
+		if pe := runRecovered(func() { err = fn(s.ctx) }); pe != nil {
+			err = pe
+		}
+		if err != nil {
+			s.fail(err)
+		}
+	})
+}
+
+// fail records the scope's first failure and cancels its context with it as the cause
+// With panic propagation a *PanicError is passed on to the parent scope
+func (s *Scope) fail(err error) {
+	s.errOnce.Do(func() {
+		s.err = err
+		s.cancel(err)
+	})
+	var pe *PanicError
+	if s.propagate && s.parent != nil && errors.As(err, &pe) {
+		s.parent.fail(pe)
+	}
+}
+
+// Wait blocks until every task of the scope and its children has finished, cancels the
+// scope's context and returns its first failure
+func (s *Scope) Wait() error {
+	s.wg.Wait()
+	s.errOnce.Do(func() {})
+	s.cancel(context.Canceled)
+	return s.err
+}
+
+// WithScope runs body with a new scope derived from ctx and waits for every goroutine the
+// body started on it, or on its children, before returning, so none can outlive the call
+// body runs in the calling goroutine; an error it returns, or a panic converted to
+// *PanicError, fails the scope like a failing task. WithScope returns the first failure
+// Like NewScope, this requires Go 1.20 or newer
+func WithScope(ctx context.Context, body func(s *Scope) error, opts ...ScopeOption) error {
+	s := NewScope(ctx, opts...)
+	if body != nil {
+		var err error
+		if pe := runRecovered(func() { err = body(s) }); pe != nil {
+			err = pe
+		}
+		if err != nil {
+			s.fail(err)
+		}
+	}
+	return s.Wait()
+}
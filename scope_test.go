@@ -0,0 +1,115 @@
+//go:build go1.20
+
+package tsafe
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScope(t *testing.T) {
+	t.Run("should return the first error and cancel siblings", func(t *testing.T) {
+		s := NewScope(context.Background())
+		failure := errors.New("task failed")
+		s.Go(func(ctx context.Context) error {
+			<-ctx.Done()
+			return nil
+		})
+		s.Go(func(ctx context.Context) error { return failure })
+
+		assert.Equal(t, failure, s.Wait())
+		assert.Equal(t, failure, context.Cause(s.Context()))
+	})
+
+	t.Run("should propagate a child panic to the root", func(t *testing.T) {
+		root := NewScope(context.Background(), WithPanicPropagation())
+		sibling := root.Child()
+		sibling.Go(func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		root.Child().Child().Go(func(ctx context.Context) error {
+			panic("child panic")
+		})
+
+		err := root.Wait()
+		var pe *PanicError
+		if assert.True(t, errors.As(err, &pe)) {
+			assert.Equal(t, "child panic", pe.Value)
+		}
+		assert.Same(t, pe, context.Cause(root.Context()))
+		assert.Same(t, pe, context.Cause(sibling.Context()))
+	})
+
+	t.Run("should keep child panics local without propagation", func(t *testing.T) {
+		root := NewScope(context.Background())
+		child := root.Child()
+		child.Go(func(ctx context.Context) error { panic("local panic") })
+
+		assert.NoError(t, root.Wait())
+		assert.ErrorIs(t, child.Wait(), ErrPanic)
+	})
+}
+
+func TestWithScope(t *testing.T) {
+	t.Run("should wait for every task before returning", func(t *testing.T) {
+		var finished int32
+		err := WithScope(context.Background(), func(s *Scope) error {
+			for i := 0; i < 5; i++ {
+				s.Go(func(ctx context.Context) error {
+					time.Sleep(5 * time.Millisecond)
+					atomic.AddInt32(&finished, 1)
+					return nil
+				})
+			}
+			s.Child().Go(func(ctx context.Context) error {
+				time.Sleep(5 * time.Millisecond)
+				atomic.AddInt32(&finished, 1)
+				return nil
+			})
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, int32(6), atomic.LoadInt32(&finished))
+	})
+
+	t.Run("should return the first task panic and cancel the others", func(t *testing.T) {
+		err := WithScope(context.Background(), func(s *Scope) error {
+			s.Go(func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			})
+			s.Go(func(ctx context.Context) error { panic("task panic") })
+			return nil
+		})
+		var pe *PanicError
+		if assert.True(t, errors.As(err, &pe)) {
+			assert.Equal(t, "task panic", pe.Value)
+		}
+	})
+
+	t.Run("should fail the scope when the body panics", func(t *testing.T) {
+		canceled := make(chan struct{})
+		err := WithScope(context.Background(), func(s *Scope) error {
+			s.Go(func(ctx context.Context) error {
+				<-ctx.Done()
+				close(canceled)
+				return nil
+			})
+			panic("body panic")
+		})
+		assert.ErrorIs(t, err, ErrPanic)
+		<-canceled
+	})
+
+	t.Run("should return the body error", func(t *testing.T) {
+		sentinel := errors.New("body failed")
+		err := WithScope(context.Background(), func(s *Scope) error { return sentinel })
+		assert.Equal(t, sentinel, err)
+	})
+}
@@ -0,0 +1,126 @@
+package tsafe
+
+import (
+	"reflect"
+	"sync"
+	"time"
+)
+
+// SelectCase is one case of a GoSelect loop, created by OnReceive or OnTimeout
+type SelectCase struct {
+	ch        reflect.Value
+	handle    func(v reflect.Value)
+	timeout   time.Duration
+	onTimeout func()
+}
+
+// OnReceive returns a case calling fn with every value received from ch
+// Once ch is closed the case is removed from the loop
+func OnReceive[T any](ch <-chan T, fn func(T)) SelectCase {
+	return SelectCase{
+		ch: reflect.ValueOf(ch),
+		handle: func(v reflect.Value) {
+			fn(v.Interface().(T))
+		},
+	}
+}
+
+// OnTimeout returns a case calling fn whenever no other case fired for d
+// Only the last timeout case passed to GoSelect is used
+func OnTimeout(d time.Duration, fn func()) SelectCase {
+	return SelectCase{timeout: d, onTimeout: fn}
+}
+
+// Selector controls a select loop started by GoSelect
+type Selector struct {
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+// Stop breaks the loop; the current handler, if any, finishes first
+// It does not wait, so it may be called from a handler. Further calls are no-ops
+func (s *Selector) Stop() {
+	s.stopOnce.Do(func() { close(s.stop) })
+}
+
+// Done returns a channel closed once the loop has exited
+func (s *Selector) Done() <-chan struct{} {
+	return s.done
+}
+
+// GoSelect starts a recovered goroutine running a select loop over the given cases
+// Every received value is passed to its case's handler. A panicking handler is logged with
+// the configured logger and the loop continues. The loop ends when Stop is called or, unless
+// there is a timeout case, once every receive channel is closed; a loop with a timeout case,
+// including one with only a timeout case, keeps firing it until Stop
+func GoSelect(cases ...SelectCase) *Selector {
+	s := &Selector{stop: make(chan struct{}), done: make(chan struct{})}
+
+	var (
+		receives []SelectCase
+		timeout  *SelectCase
+	)
+	for i := range cases {
+		switch c := cases[i]; {
+		case c.onTimeout != nil:
+			timeout = &cases[i]
+		case c.handle != nil && c.ch.IsValid() && !c.ch.IsNil():
+			receives = append(receives, c)
+		}
+	}
+
+	spawn(func() {
+		defer close(s.done)
+		runSelect(s.stop, receives, timeout)
+	})
+	return s
+}
+
+// runSelect drives a GoSelect loop until stop is closed or no cases are left
+func runSelect(stop chan struct{}, receives []SelectCase, timeout *SelectCase) {
+	var timer *time.Timer
+	if timeout != nil {
+		timer = time.NewTimer(timeout.timeout)
+		defer timer.Stop()
+	}
+
+	for len(receives) > 0 || timer != nil {
+		cases := make([]reflect.SelectCase, 0, len(receives)+2)
+		for _, c := range receives {
+			cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: c.ch})
+		}
+		cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(stop)})
+		if timer != nil {
+			cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(timer.C)})
+		}
+
+		chosen, v, ok := reflect.Select(cases)
+		var handler func()
+		switch {
+		case chosen == len(receives):
+			return
+		case chosen > len(receives):
+			handler = timeout.onTimeout
+		case !ok:
+			receives = append(receives[:chosen:chosen], receives[chosen+1:]...)
+			continue
+		default:
+			handle := receives[chosen].handle
+			handler = func() { handle(v) }
+		}
+
+		if pe := runRecovered(handler); pe != nil {
+			reportPanic(pe.Value, pe.Stack, nil)
+		}
+		if timer != nil {
+			if !timer.Stop() {
+				select { // Drain a fire that raced with the handled case
+				case <-timer.C:
+				default:
+				}
+			}
+			timer.Reset(timeout.timeout)
+		}
+	}
+}
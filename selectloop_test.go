@@ -0,0 +1,101 @@
+package tsafe
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoSelect(t *testing.T) {
+	t.Run("should dispatch received values to their handlers", func(t *testing.T) {
+		ints := make(chan int)
+		strs := make(chan string)
+		got := make(chan any, 2)
+		s := GoSelect(
+			OnReceive(ints, func(n int) { got <- n }),
+			OnReceive(strs, func(v string) { got <- v }),
+		)
+		defer s.Stop()
+
+		ints <- 1
+		assert.Equal(t, 1, <-got)
+		strs <- "a"
+		assert.Equal(t, "a", <-got)
+	})
+
+	t.Run("should log handler panics and keep looping", func(t *testing.T) {
+		mock := &mockLogger{}
+		SetLogger(mock)
+		defer SetLogger(&defaultLoggerImpl{})
+
+		ch := make(chan int)
+		got := make(chan int, 1)
+		s := GoSelect(OnReceive(ch, func(n int) {
+			if n == 0 {
+				panic("select panic")
+			}
+			got <- n
+		}))
+		defer s.Stop()
+
+		ch <- 0
+		ch <- 2
+		assert.Equal(t, 2, <-got)
+		assert.Equal(t, 1, mock.getCallCount())
+	})
+
+	t.Run("should call the timeout handler when idle", func(t *testing.T) {
+		var timeouts int32
+		s := GoSelect(
+			OnReceive(make(chan int), func(int) {}),
+			OnTimeout(5*time.Millisecond, func() { atomic.AddInt32(&timeouts, 1) }),
+		)
+		defer s.Stop()
+
+		assert.Eventually(t, func() bool { return atomic.LoadInt32(&timeouts) >= 2 }, time.Second, time.Millisecond)
+	})
+
+	t.Run("should keep firing a timeout-only loop until stopped", func(t *testing.T) {
+		var timeouts int32
+		s := GoSelect(OnTimeout(5*time.Millisecond, func() { atomic.AddInt32(&timeouts, 1) }))
+
+		assert.Eventually(t, func() bool { return atomic.LoadInt32(&timeouts) >= 2 }, time.Second, time.Millisecond)
+		select {
+		case <-s.Done():
+			t.Fatal("loop ended before Stop")
+		default:
+		}
+		s.Stop()
+		select {
+		case <-s.Done():
+		case <-time.After(time.Second):
+			t.Fatal("loop did not stop")
+		}
+	})
+
+	t.Run("should stop the loop", func(t *testing.T) {
+		s := GoSelect(OnReceive(make(chan int), func(int) {}))
+		s.Stop()
+		s.Stop()
+
+		select {
+		case <-s.Done():
+		case <-time.After(time.Second):
+			t.Fatal("loop did not stop")
+		}
+	})
+
+	t.Run("should end once every channel is closed", func(t *testing.T) {
+		ch := make(chan int)
+		s := GoSelect(OnReceive(ch, func(int) {}))
+		close(ch)
+
+		select {
+		case <-s.Done():
+		case <-time.After(time.Second):
+			t.Fatal("loop did not end")
+		}
+	})
+}
@@ -0,0 +1,93 @@
+package tsafe
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// Semaphore limits the number of concurrent holders of a resource
+// It records contention metrics so callers can size the limit correctly
+type Semaphore struct {
+	slots    chan struct{}
+	acquired int64 // number of slots currently held
+	waiting  int64 // number of callers currently blocked in Acquire
+	maxWait  int64 // longest observed Acquire wait in nanoseconds
+}
+
+// NewSemaphore creates a semaphore allowing up to n concurrent holders
+// A non-positive n is treated as 1
+func NewSemaphore(n int) *Semaphore {
+	if n <= 0 {
+		n = 1
+	}
+	return &Semaphore{slots: make(chan struct{}, n)}
+}
+
+// Acquire blocks until a slot is available or ctx is done
+// It returns ctx.Err() if the context ends before a slot is obtained, in which case
+// the caller does not hold a slot and is no longer counted as waiting
+func (s *Semaphore) Acquire(ctx context.Context) error {
+	// Fast path: take a free slot without registering as a waiter
+	select {
+	case s.slots <- struct{}{}:
+		atomic.AddInt64(&s.acquired, 1)
+		return nil
+	default:
+	}
+
+	start := time.Now()
+	atomic.AddInt64(&s.waiting, 1)
+	defer func() {
+		atomic.AddInt64(&s.waiting, -1)
+		s.recordWait(time.Since(start))
+	}()
+
+	select {
+	case s.slots <- struct{}{}:
+		atomic.AddInt64(&s.acquired, 1)
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// TryAcquire obtains a slot without blocking and reports whether it succeeded
+func (s *Semaphore) TryAcquire() bool {
+	select {
+	case s.slots <- struct{}{}:
+		atomic.AddInt64(&s.acquired, 1)
+		return true
+	default:
+		return false
+	}
+}
+
+// Release returns a slot previously obtained with Acquire or TryAcquire
+// It panics if called more times than the semaphore was acquired
+func (s *Semaphore) Release() {
+	select {
+	case <-s.slots:
+		atomic.AddInt64(&s.acquired, -1)
+	default:
+		panic("tsafe: semaphore released without being acquired")
+	}
+}
+
+// Stats returns the number of held slots, the number of blocked callers and
+// the longest time any caller has waited in Acquire
+func (s *Semaphore) Stats() (acquired, waiting int, maxWait time.Duration) {
+	return int(atomic.LoadInt64(&s.acquired)),
+		int(atomic.LoadInt64(&s.waiting)),
+		time.Duration(atomic.LoadInt64(&s.maxWait))
+}
+
+// recordWait updates the maximum observed wait time
+func (s *Semaphore) recordWait(d time.Duration) {
+	for {
+		current := atomic.LoadInt64(&s.maxWait)
+		if int64(d) <= current || atomic.CompareAndSwapInt64(&s.maxWait, current, int64(d)) {
+			return
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package tsafe
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSemaphore(t *testing.T) {
+	t.Run("should limit concurrent holders", func(t *testing.T) {
+		sem := NewSemaphore(2)
+		assert.True(t, sem.TryAcquire())
+		assert.True(t, sem.TryAcquire())
+		assert.False(t, sem.TryAcquire())
+
+		sem.Release()
+		assert.True(t, sem.TryAcquire())
+	})
+
+	t.Run("should track waiting callers under contention", func(t *testing.T) {
+		sem := NewSemaphore(1)
+		assert.NoError(t, sem.Acquire(context.Background()))
+
+		var wg sync.WaitGroup
+		for i := 0; i < 3; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := sem.Acquire(context.Background()); err == nil {
+					time.Sleep(time.Millisecond)
+					sem.Release()
+				}
+			}()
+		}
+
+		assert.Eventually(t, func() bool {
+			_, waiting, _ := sem.Stats()
+			return waiting == 3
+		}, time.Second, time.Millisecond)
+
+		acquired, _, _ := sem.Stats()
+		assert.Equal(t, 1, acquired)
+
+		time.Sleep(5 * time.Millisecond)
+		sem.Release()
+		wg.Wait()
+
+		acquired, waiting, maxWait := sem.Stats()
+		assert.Equal(t, 0, acquired)
+		assert.Equal(t, 0, waiting)
+		assert.GreaterOrEqual(t, maxWait, 5*time.Millisecond)
+	})
+
+	t.Run("should not leave a phantom waiter on cancellation", func(t *testing.T) {
+		sem := NewSemaphore(1)
+		assert.True(t, sem.TryAcquire())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- sem.Acquire(ctx)
+		}()
+
+		assert.Eventually(t, func() bool {
+			_, waiting, _ := sem.Stats()
+			return waiting == 1
+		}, time.Second, time.Millisecond)
+
+		cancel()
+		assert.ErrorIs(t, <-errCh, context.Canceled)
+
+		acquired, waiting, _ := sem.Stats()
+		assert.Equal(t, 1, acquired)
+		assert.Equal(t, 0, waiting)
+	})
+
+	t.Run("should respect context deadline", func(t *testing.T) {
+		sem := NewSemaphore(1)
+		assert.True(t, sem.TryAcquire())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		assert.ErrorIs(t, sem.Acquire(ctx), context.DeadlineExceeded)
+	})
+
+	t.Run("should panic on release without acquire", func(t *testing.T) {
+		sem := NewSemaphore(1)
+		assert.Panics(t, func() {
+			sem.Release()
+		})
+	})
+}
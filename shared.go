@@ -0,0 +1,76 @@
+package tsafe
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrSharedTypeMismatch is returned by GoShared when the execution it joined produced a
+// result of a different type, because callers used the same key with different types
+var ErrSharedTypeMismatch = errors.New("tsafe: shared result has a different type")
+
+// sharedCall is an in-flight or completed GoShared execution
+type sharedCall struct {
+	wg  sync.WaitGroup
+	val any
+	err error
+}
+
+// Internal singleflight state keyed by string
+var (
+	sharedCalls = make(map[string]*sharedCall)
+	sharedMutex sync.Mutex
+)
+
+// GoShared executes fn once for all concurrent callers using the same key
+// Callers arriving while an execution is in flight wait for it and receive its result
+// instead of starting duplicate work. A panic in fn is recovered and returned to every
+// waiter as a *PanicError, and if fn calls runtime.Goexit the waiters get ErrGoexit. Keys share one namespace regardless of T, so callers using
+// the same key must agree on the result type; a caller joining an execution of another
+// type gets ErrSharedTypeMismatch
+func GoShared[T any](key string, fn func() (T, error)) (T, error) {
+	val, err := doShared(key, func() (any, error) {
+		return fn()
+	})
+	var result T
+	if val == nil {
+		return result, err // Nil interface results and panics carry no value to check
+	}
+	result, ok := val.(T)
+	if !ok {
+		return result, fmt.Errorf("%w: key %q holds %T, want %T", ErrSharedTypeMismatch, key, val, result)
+	}
+	return result, err
+}
+
+// doShared implements singleflight semantics over untyped results
+func doShared(key string, fn func() (any, error)) (any, error) {
+	sharedMutex.Lock()
+	if c, ok := sharedCalls[key]; ok {
+		sharedMutex.Unlock()
+		c.wg.Wait()
+		return c.val, c.err
+	}
+	c := &sharedCall{}
+	c.wg.Add(1)
+	sharedCalls[key] = c
+	sharedMutex.Unlock()
+
+	// The cleanup is deferred so it also runs when fn calls runtime.Goexit
+	completed := false
+	defer func() {
+		if !completed {
+			c.val, c.err = nil, ErrGoexit
+		}
+		sharedMutex.Lock()
+		delete(sharedCalls, key)
+		sharedMutex.Unlock()
+		c.wg.Done()
+	}()
+	if pe := runRecovered(func() { c.val, c.err = fn() }); pe != nil {
+		c.val, c.err = nil, pe
+	}
+	completed = true
+	return c.val, c.err
+}
@@ -0,0 +1,150 @@
+package tsafe
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoShared(t *testing.T) {
+	t.Run("should coalesce concurrent calls with the same key", func(t *testing.T) {
+		var executions int32
+		release := make(chan struct{})
+		started := make(chan struct{})
+
+		run := func() (int, error) {
+			return GoShared("coalesce", func() (int, error) {
+				atomic.AddInt32(&executions, 1)
+				close(started)
+				<-release
+				return 42, nil
+			})
+		}
+
+		var wg sync.WaitGroup
+		results := make([]int, 10)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[0], _ = run()
+		}()
+		<-started
+
+		for i := 1; i < 10; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				results[i], _ = run()
+			}(i)
+		}
+
+		// Give every other caller time to join the in-flight execution
+		time.Sleep(50 * time.Millisecond)
+
+		close(release)
+		wg.Wait()
+
+		assert.Equal(t, int32(1), atomic.LoadInt32(&executions))
+		for _, r := range results {
+			assert.Equal(t, 42, r)
+		}
+	})
+
+	t.Run("should return errors to the caller", func(t *testing.T) {
+		expected := errors.New("failed")
+		v, err := GoShared("error", func() (string, error) {
+			return "", expected
+		})
+		assert.Equal(t, "", v)
+		assert.Equal(t, expected, err)
+	})
+
+	t.Run("should convert panics into PanicError", func(t *testing.T) {
+		v, err := GoShared("panic", func() (int, error) {
+			panic("shared panic")
+		})
+		assert.Equal(t, 0, v)
+
+		var pe *PanicError
+		if assert.True(t, errors.As(err, &pe)) {
+			assert.Equal(t, "shared panic", pe.Value)
+		}
+
+		// The key is released after a panic so later calls run again
+		v, err = GoShared("panic", func() (int, error) {
+			return 1, nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, v)
+	})
+	t.Run("should report a type mismatch between callers of a key", func(t *testing.T) {
+		release := make(chan struct{})
+		first := make(chan int, 1)
+		go func() {
+			v, _ := GoShared("mismatch", func() (int, error) {
+				<-release
+				return 7, nil
+			})
+			first <- v
+		}()
+		assert.Eventually(t, func() bool {
+			sharedMutex.Lock()
+			defer sharedMutex.Unlock()
+			return sharedCalls["mismatch"] != nil
+		}, time.Second, time.Millisecond)
+
+		second := make(chan error, 1)
+		go func() {
+			_, err := GoShared("mismatch", func() (string, error) {
+				return "unused", nil
+			})
+			second <- err
+		}()
+		time.Sleep(50 * time.Millisecond)
+		close(release)
+
+		assert.Equal(t, 7, <-first)
+		assert.ErrorIs(t, <-second, ErrSharedTypeMismatch)
+	})
+	t.Run("should release the key when fn calls runtime.Goexit", func(t *testing.T) {
+		release := make(chan struct{})
+		exited := make(chan struct{})
+		go func() {
+			defer close(exited)
+			_, _ = GoShared("goexit", func() (int, error) {
+				<-release
+				runtime.Goexit()
+				return 0, nil
+			})
+		}()
+		assert.Eventually(t, func() bool {
+			sharedMutex.Lock()
+			defer sharedMutex.Unlock()
+			return sharedCalls["goexit"] != nil
+		}, time.Second, time.Millisecond)
+
+		waiter := make(chan error, 1)
+		go func() {
+			_, err := GoShared("goexit", func() (int, error) { return 1, nil })
+			waiter <- err
+		}()
+		time.Sleep(50 * time.Millisecond)
+		close(release)
+		<-exited
+
+		select {
+		case err := <-waiter:
+			assert.ErrorIs(t, err, ErrGoexit)
+		case <-time.After(time.Second):
+			t.Fatal("waiter blocked after runtime.Goexit")
+		}
+		v, err := GoShared("goexit", func() (int, error) { return 2, nil })
+		assert.NoError(t, err)
+		assert.Equal(t, 2, v)
+	})
+}
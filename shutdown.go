@@ -0,0 +1,65 @@
+package tsafe
+
+import (
+	"context"
+	"errors"
+	"runtime/debug"
+	"sync"
+)
+
+// ErrSpawnAfterShutdown is logged as a warning when a goroutine is started after the shutdown context ended
+var ErrSpawnAfterShutdown = errors.New("tsafe: goroutine started after shutdown")
+
+// ShutdownBehavior is what GoWithRecover does with functions started after the shutdown context ended
+type ShutdownBehavior int32
+
+const (
+	// ShutdownDrop discards the function without running it (the default)
+	ShutdownDrop ShutdownBehavior = iota
+	// ShutdownRunSync runs the function with panic recovery in the calling goroutine
+	ShutdownRunSync
+)
+
+// Thread-safe shutdown state
+var (
+	shutdownCtx      context.Context
+	shutdownBehavior ShutdownBehavior
+	shutdownMutex    sync.RWMutex
+)
+
+// SetShutdownContext installs a global kill switch for spawning: once ctx is done, Go and
+// the other package-level spawners built on GoWithRecover log ErrSpawnAfterShutdown as a
+// warning and do not start a goroutine; SetShutdownBehavior decides what happens instead
+// Goroutines already running are not affected. Passing nil removes the shutdown context
+// This function is thread-safe; each concurrent Go call either spawns or observes the ended context
+func SetShutdownContext(ctx context.Context) {
+	shutdownMutex.Lock()
+	defer shutdownMutex.Unlock()
+	shutdownCtx = ctx
+}
+
+// SetShutdownBehavior chooses between dropping late work and running it synchronously
+// ShutdownDrop keeps shutdown fast and bounded but loses the work, which is only acceptable
+// for work that is safe to lose. ShutdownRunSync never loses work, but blocks each late
+// caller until its function returns, so slow or blocking functions can stall shutdown
+// This function is thread-safe
+func SetShutdownBehavior(b ShutdownBehavior) {
+	shutdownMutex.Lock()
+	defer shutdownMutex.Unlock()
+	shutdownBehavior = b
+}
+
+// afterShutdown reports whether the shutdown context has ended and, if so, the configured behavior
+func afterShutdown() (bool, ShutdownBehavior) {
+	shutdownMutex.RLock()
+	defer shutdownMutex.RUnlock()
+	if shutdownCtx == nil || shutdownCtx.Err() == nil {
+		return false, shutdownBehavior
+	}
+	return true, shutdownBehavior
+}
+
+// warnSpawnAfterShutdown logs ErrSpawnAfterShutdown with the stack of the late caller
+func warnSpawnAfterShutdown() {
+	logWarning(ErrSpawnAfterShutdown, debug.Stack())
+}
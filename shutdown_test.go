@@ -0,0 +1,59 @@
+package tsafe
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetShutdownContext(t *testing.T) {
+	t.Run("should drop and warn after the context ends", func(t *testing.T) {
+		logger := &mockLeveledLogger{}
+		SetLogger(logger)
+		defer SetLogger(&defaultLoggerImpl{})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		SetShutdownContext(ctx)
+		defer SetShutdownContext(nil)
+
+		ran := make(chan struct{}, 1)
+		Go(func() { ran <- struct{}{} })
+		<-ran
+
+		cancel()
+		Go(func() { ran <- struct{}{} })
+		select {
+		case <-ran:
+			t.Fatal("goroutine started after shutdown")
+		case <-time.After(20 * time.Millisecond):
+		}
+
+		assert.Equal(t, []Level{LevelWarn}, logger.getLevels())
+		assert.Equal(t, ErrSpawnAfterShutdown, logger.getLastError())
+	})
+
+	t.Run("should run synchronously when configured", func(t *testing.T) {
+		logger := &mockLogger{}
+		SetLogger(logger)
+		defer SetLogger(&defaultLoggerImpl{})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		SetShutdownContext(ctx)
+		defer SetShutdownContext(nil)
+		SetShutdownBehavior(ShutdownRunSync)
+		defer SetShutdownBehavior(ShutdownDrop)
+
+		ran := false
+		Go(func() { ran = true })
+		assert.True(t, ran)
+
+		assert.NotPanics(t, func() {
+			Go(func() { panic("late panic") })
+		})
+		assert.Equal(t, 3, logger.getCallCount()) // two warnings and the recovered panic
+		assert.Equal(t, "late panic", logger.getLastError())
+	})
+}
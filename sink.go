@@ -0,0 +1,175 @@
+package tsafe
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// PanicEvent is the structured description of a recovered panic delivered to sinks
+type PanicEvent struct {
+	// Time is when the panic was reported
+	Time time.Time
+	// Value is the value passed to panic
+	Value any
+	// Stack is the stack trace captured at recovery, after the configured trimming
+	Stack []byte
+	// Fields holds the merged global and per-goroutine fields, or nil
+	Fields map[string]any
+	// GoroutineID is the runtime id of the goroutine that panicked, or 0 if unknown
+	GoroutineID uint64
+	// Level is the level the panic was classified at
+	Level Level
+	// Causes holds the messages of the errors wrapped by Value, outermost first, found by
+	// walking errors.Unwrap, or nil if Value is not an error or wraps nothing
+	Causes []string
+	// MemStats is a memory snapshot taken at recovery, or nil unless SetIncludeMemStats is on
+	MemStats *MemStats
+}
+
+// Sink receives every reported panic as a structured event
+// Sinks are the extension point for observability backends; several can be registered at once
+// Emit is called synchronously from the recovering goroutine and should not block
+type Sink interface {
+	// Emit delivers a panic event
+	Emit(event PanicEvent)
+}
+
+// sinkEntry wraps a registered sink so it can be removed even if it is not comparable
+type sinkEntry struct {
+	sink Sink
+}
+
+// Thread-safe sink registry
+var (
+	sinks      []*sinkEntry
+	sinksMutex sync.RWMutex
+)
+
+// RegisterSink adds a sink receiving every reported panic and returns a function removing it
+// A panicking sink is recovered so it cannot break the recovery path or other sinks
+// This function is thread-safe
+func RegisterSink(s Sink) (unregister func()) {
+	if s == nil {
+		return func() {}
+	}
+
+	entry := &sinkEntry{sink: s}
+	sinksMutex.Lock()
+	sinks = append(sinks, entry)
+	sinksMutex.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			sinksMutex.Lock()
+			defer sinksMutex.Unlock()
+			for i, e := range sinks {
+				if e == entry {
+					sinks = append(sinks[:i:i], sinks[i+1:]...)
+					return
+				}
+			}
+		})
+	}
+}
+
+// getSinks returns the registered sinks in a thread-safe manner
+func getSinks() []*sinkEntry {
+	sinksMutex.RLock()
+	defer sinksMutex.RUnlock()
+	return sinks
+}
+
+// emitToSinks delivers an event to every registered sink
+// Events a sink fails to receive are handed to the dead-letter sink
+func emitToSinks(event PanicEvent) {
+	for _, e := range getSinks() {
+		if callSafely(func() { e.sink.Emit(event) }) != nil {
+			deadLetter(event)
+		}
+	}
+}
+
+// callSafely runs fn and swallows any panic, for calls into user code from the recovery path
+func callSafely(fn func()) (recovered any) {
+	defer func() {
+		recovered = recover()
+	}()
+	fn()
+	return nil
+}
+
+// loggerSink adapts a Logger to the Sink interface
+type loggerSink struct {
+	logger Logger
+}
+
+// Emit implements the Sink interface by logging the event
+func (s loggerSink) Emit(event PanicEvent) {
+	logEvent(nil, s.logger, event)
+}
+
+// Flush implements the Flusher interface by flushing the logger if it implements Flusher
+func (s loggerSink) Flush() error {
+	if f, ok := s.logger.(Flusher); ok {
+		return f.Flush()
+	}
+	return nil
+}
+
+// NewLoggerSink adapts a Logger into a Sink, so several loggers can receive panics at once
+// The sink implements Flusher, forwarding to the logger if it implements Flusher itself
+func NewLoggerSink(l Logger) Sink {
+	return loggerSink{logger: l}
+}
+
+// newPanicEvent builds the event for a recovered panic
+func newPanicEvent(value any, stack []byte, fields map[string]any, level Level) PanicEvent {
+	return PanicEvent{
+		Time:        time.Now(),
+		Value:       value,
+		Stack:       prepareStack(stack),
+		Fields:      fields,
+		GoroutineID: goroutineID(stack),
+		Level:       level,
+		Causes:      causeChain(value),
+		MemStats:    currentMemStats(),
+	}
+}
+
+// maxCauses bounds the cause chain, guarding against errors whose Unwrap forms a cycle
+const maxCauses = 32
+
+// causeChain returns the messages of the errors wrapped by value, outermost first
+func causeChain(value any) []string {
+	err, ok := value.(error)
+	if !ok {
+		return nil
+	}
+	var causes []string
+	for cause := errors.Unwrap(err); cause != nil && len(causes) < maxCauses; cause = errors.Unwrap(cause) {
+		causes = append(causes, cause.Error())
+	}
+	return causes
+}
+
+// goroutineID parses the goroutine id from the "goroutine N [status]:" header of a stack
+func goroutineID(stack []byte) uint64 {
+	const prefix = "goroutine "
+	if !bytes.HasPrefix(stack, []byte(prefix)) {
+		return 0
+	}
+	rest := stack[len(prefix):]
+	end := bytes.IndexByte(rest, ' ')
+	if end < 0 {
+		return 0
+	}
+	id, err := strconv.ParseUint(string(rest[:end]), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return id
+}
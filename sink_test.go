@@ -0,0 +1,115 @@
+package tsafe
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingSink stores every emitted event
+type recordingSink struct {
+	mu     sync.Mutex
+	events []PanicEvent
+}
+
+func (s *recordingSink) Emit(event PanicEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, event)
+}
+
+func (s *recordingSink) getEvents() []PanicEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]PanicEvent(nil), s.events...)
+}
+
+// panickingSink panics on every event
+type panickingSink struct{}
+
+func (panickingSink) Emit(PanicEvent) {
+	panic("sink failure")
+}
+
+func TestRegisterSink(t *testing.T) {
+	t.Run("should emit structured events to every sink", func(t *testing.T) {
+		originalLogger := getLogger()
+		SetLogger(&mockLogger{})
+		defer SetLogger(originalLogger)
+
+		first, second := &recordingSink{}, &recordingSink{}
+		defer RegisterSink(first)()
+		defer RegisterSink(second)()
+
+		GoWithFields(map[string]any{"task": "sync"}, func() {
+			panic("sink panic")
+		})
+
+		for _, s := range []*recordingSink{first, second} {
+			s := s
+			assert.Eventually(t, func() bool {
+				return len(s.getEvents()) == 1
+			}, 100*time.Millisecond, time.Millisecond)
+
+			event := s.getEvents()[0]
+			assert.Equal(t, "sink panic", event.Value)
+			assert.Equal(t, map[string]any{"task": "sync"}, event.Fields)
+			assert.NotZero(t, event.GoroutineID)
+			assert.NotEmpty(t, event.Stack)
+			assert.Equal(t, LevelError, event.Level)
+			assert.WithinDuration(t, time.Now(), event.Time, time.Second)
+		}
+	})
+
+	t.Run("should stop emitting after unregister", func(t *testing.T) {
+		originalLogger := getLogger()
+		mock := &mockLogger{}
+		SetLogger(mock)
+		defer SetLogger(originalLogger)
+
+		sink := &recordingSink{}
+		unregister := RegisterSink(sink)
+		unregister()
+		unregister() // Calling twice is harmless
+
+		Go(func() {
+			panic("after unregister")
+		})
+		assert.Eventually(t, func() bool {
+			return mock.getCallCount() == 1
+		}, 100*time.Millisecond, time.Millisecond)
+		assert.Empty(t, sink.getEvents())
+	})
+
+	t.Run("should isolate panicking sinks", func(t *testing.T) {
+		originalLogger := getLogger()
+		mock := &mockLogger{}
+		SetLogger(mock)
+		defer SetLogger(originalLogger)
+
+		defer RegisterSink(panickingSink{})()
+		sink := &recordingSink{}
+		defer RegisterSink(sink)()
+
+		Go(func() {
+			panic("isolated")
+		})
+		assert.Eventually(t, func() bool {
+			return mock.getCallCount() == 1 && len(sink.getEvents()) == 1
+		}, 100*time.Millisecond, time.Millisecond)
+	})
+}
+
+func TestNewLoggerSink(t *testing.T) {
+	mock := &mockLogger{}
+	NewLoggerSink(mock).Emit(PanicEvent{Value: "as sink", Stack: []byte("stack")})
+	assert.Equal(t, "as sink", mock.getLastError())
+}
+
+func TestGoroutineID(t *testing.T) {
+	assert.Equal(t, uint64(42), goroutineID([]byte("goroutine 42 [running]:\nmain.main()")))
+	assert.Equal(t, uint64(0), goroutineID([]byte("not a stack")))
+	assert.Equal(t, uint64(0), goroutineID([]byte("goroutine x [running]:")))
+}
@@ -0,0 +1,32 @@
+package tsafe
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// snapshotPanicField is the field reporting a panic raised by the snapshot function itself
+const snapshotPanicField = "snapshot_panic"
+
+// GoWithSnapshot starts a goroutine with automatic panic recovery that captures extra state on panic
+// When goroutine panics, snapshot is called to collect relevant values (typically ones the
+// goroutine closes over, such as its inputs) and the result is attached to the panic report as
+// fields for loggers implementing FieldLogger. A panic inside snapshot is recovered as well and
+// reported in the "snapshot_panic" field instead of compounding the failure
+func GoWithSnapshot(goroutine func(), snapshot func() map[string]any) {
+	GoWithRecover(goroutine, func(err any) {
+		stack := debug.Stack()
+		reportPanic(err, stack, takeSnapshot(snapshot))
+	})
+}
+
+// takeSnapshot calls snapshot with panic recovery
+func takeSnapshot(snapshot func() map[string]any) (fields map[string]any) {
+	if snapshot == nil {
+		return nil
+	}
+	if r := callSafely(func() { fields = snapshot() }); r != nil {
+		return map[string]any{snapshotPanicField: fmt.Sprint(r)}
+	}
+	return fields
+}
@@ -0,0 +1,79 @@
+package tsafe
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoWithSnapshot(t *testing.T) {
+	t.Run("should attach the snapshot to the panic report", func(t *testing.T) {
+		mock := &mockFieldLogger{}
+		originalLogger := getLogger()
+		SetLogger(mock)
+		defer SetLogger(originalLogger)
+
+		userID := 42
+		GoWithSnapshot(func() {
+			panic("snapshot panic")
+		}, func() map[string]any {
+			return map[string]any{"user_id": userID}
+		})
+
+		assert.Eventually(t, func() bool {
+			calls, _ := mock.getFieldCalls()
+			return calls == 1
+		}, 100*time.Millisecond, time.Millisecond)
+		_, fields := mock.getFieldCalls()
+		assert.Equal(t, map[string]any{"user_id": 42}, fields)
+	})
+
+	t.Run("should recover a panicking snapshot", func(t *testing.T) {
+		mock := &mockFieldLogger{}
+		originalLogger := getLogger()
+		SetLogger(mock)
+		defer SetLogger(originalLogger)
+
+		GoWithSnapshot(func() {
+			panic("original panic")
+		}, func() map[string]any {
+			panic("snapshot failed")
+		})
+
+		assert.Eventually(t, func() bool {
+			calls, _ := mock.getFieldCalls()
+			return calls == 1
+		}, 100*time.Millisecond, time.Millisecond)
+		_, fields := mock.getFieldCalls()
+		assert.Equal(t, map[string]any{"snapshot_panic": "snapshot failed"}, fields)
+	})
+
+	t.Run("should not call the snapshot without a panic", func(t *testing.T) {
+		called := make(chan struct{}, 1)
+		done := make(chan struct{})
+		GoWithSnapshot(func() {
+			close(done)
+		}, func() map[string]any {
+			called <- struct{}{}
+			return nil
+		})
+		<-done
+		time.Sleep(10 * time.Millisecond)
+		assert.Len(t, called, 0)
+	})
+
+	t.Run("should handle nil snapshot", func(t *testing.T) {
+		mock := &mockLogger{}
+		originalLogger := getLogger()
+		SetLogger(mock)
+		defer SetLogger(originalLogger)
+
+		GoWithSnapshot(func() {
+			panic("no snapshot")
+		}, nil)
+		assert.Eventually(t, func() bool {
+			return mock.getCallCount() == 1
+		}, 100*time.Millisecond, time.Millisecond)
+	})
+}
@@ -0,0 +1,75 @@
+package tsafe
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"sync/atomic"
+)
+
+// trimInternalFrames enables removal of tsafe and runtime recovery frames from logged stacks
+var trimInternalFrames int32
+
+// packagePath is the import path of this package, derived at runtime so trimming
+// keeps working when the package is vendored or forked
+var packagePath = reflect.TypeOf(PanicError{}).PkgPath()
+
+// SetTrimInternalFrames enables or disables trimming of tsafe's own frames and the
+// runtime panic/recovery frames from stacks before they are logged, so the first
+// frame shown is the user's panic site
+// This function is thread-safe
+func SetTrimInternalFrames(enabled bool) {
+	var v int32
+	if enabled {
+		v = 1
+	}
+	atomic.StoreInt32(&trimInternalFrames, v)
+}
+
+// prepareStack applies the configured stack transformations before logging
+func prepareStack(stack []byte) []byte {
+	if atomic.LoadInt32(&trimInternalFrames) == 1 {
+		return trimStack(stack)
+	}
+	return stack
+}
+
+// trimStack removes internal frames from a stack in debug.Stack format
+// Each frame is a function line followed by a tab-indented file:line line
+func trimStack(stack []byte) []byte {
+	lines := bytes.Split(stack, []byte("\n"))
+	out := make([][]byte, 0, len(lines))
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		if len(line) == 0 || line[0] == '\t' {
+			out = append(out, line)
+			continue
+		}
+		var location []byte
+		if i+1 < len(lines) && len(lines[i+1]) > 0 && lines[i+1][0] == '\t' {
+			location = lines[i+1]
+		}
+		if isInternalFrame(string(line)) {
+			if location != nil {
+				i++ // Skip the file:line of the dropped frame
+			}
+			continue
+		}
+		out = append(out, line)
+	}
+	return bytes.Join(out, []byte("\n"))
+}
+
+// isInternalFrame reports whether a stack frame belongs to tsafe or to the runtime's panic machinery
+func isInternalFrame(function string) bool {
+	function = strings.TrimPrefix(function, "created by ")
+	switch {
+	case strings.HasPrefix(function, "panic("),
+		strings.HasPrefix(function, "runtime/debug."),
+		strings.HasPrefix(function, "runtime.gopanic"):
+		return true
+	case strings.HasPrefix(function, packagePath+"."):
+		return true
+	}
+	return false
+}
@@ -0,0 +1,81 @@
+package tsafe
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tinystack/tsafe/internal/userpanic"
+)
+
+func TestPackagePath(t *testing.T) {
+	assert.Equal(t, "github.com/tinystack/tsafe", packagePath)
+}
+
+func TestTrimStack(t *testing.T) {
+	stack := strings.Join([]string{
+		"goroutine 7 [running]:",
+		"runtime/debug.Stack()",
+		"\t/usr/local/go/src/runtime/debug/stack.go:26 +0x5e",
+		packagePath + ".logPanic({0x1, 0x2})",
+		"\t/src/tsafe/goroutine.go:80 +0x25",
+		packagePath + ".GoWithRecover.func1.1()",
+		"\t/src/tsafe/goroutine.go:104 +0x4a",
+		"panic({0x1, 0x2})",
+		"\t/usr/local/go/src/runtime/panic.go:770 +0x132",
+		"main.worker()",
+		"\t/src/app/main.go:12 +0x25",
+		packagePath + ".GoWithRecover.func1()",
+		"\t/src/tsafe/goroutine.go:107 +0x55",
+		"created by " + packagePath + ".GoWithRecover in goroutine 1",
+		"\t/src/tsafe/goroutine.go:101 +0x6a",
+		"",
+	}, "\n")
+
+	trimmed := string(trimStack([]byte(stack)))
+	assert.Equal(t, strings.Join([]string{
+		"goroutine 7 [running]:",
+		"main.worker()",
+		"\t/src/app/main.go:12 +0x25",
+		"",
+	}, "\n"), trimmed)
+}
+
+func TestSetTrimInternalFrames(t *testing.T) {
+	t.Run("should start logged stacks at the panic site", func(t *testing.T) {
+		mock := &mockLogger{}
+		originalLogger := getLogger()
+		SetLogger(mock)
+		defer SetLogger(originalLogger)
+
+		SetTrimInternalFrames(true)
+		defer SetTrimInternalFrames(false)
+
+		Go(func() {
+			userpanic.Panic("trimmed panic")
+		})
+		assert.Eventually(t, func() bool {
+			return mock.getCallCount() == 1
+		}, 100*time.Millisecond, time.Millisecond)
+
+		mock.mutex.Lock()
+		stack := fmt.Sprintf("%s", mock.lastStack)
+		mock.mutex.Unlock()
+
+		lines := strings.Split(stack, "\n")
+		if assert.Greater(t, len(lines), 1) {
+			assert.Contains(t, lines[1], "userpanic.Panic")
+		}
+		assert.NotContains(t, stack, "runtime/debug.Stack")
+		assert.NotContains(t, stack, packagePath+".GoWithRecover")
+		assert.NotContains(t, stack, packagePath+".logPanic")
+	})
+
+	t.Run("should keep full stacks when disabled", func(t *testing.T) {
+		SetTrimInternalFrames(false)
+		stack := []byte("goroutine 1 [running]:\nruntime/debug.Stack()\n\tstack.go:1")
+		assert.Equal(t, stack, prepareStack(stack))
+	})
+}
@@ -0,0 +1,51 @@
+package tsafe
+
+import "sync/atomic"
+
+// Package-wide counters backing Stats
+var (
+	statsSpawned uint64 // goroutines started by tsafe
+	statsPanics  uint64 // panics recovered by tsafe
+	statsActive  int64  // tsafe goroutines currently running
+)
+
+// StatsSnapshot is a point-in-time view of the package statistics
+type StatsSnapshot struct {
+	// TotalSpawned is the cumulative number of goroutines started by tsafe
+	TotalSpawned uint64
+	// TotalPanics is the cumulative number of panics recovered by tsafe
+	TotalPanics uint64
+	// ActiveGoroutines is the number of tsafe goroutines currently running
+	ActiveGoroutines int64
+}
+
+// Stats returns a snapshot of the package statistics
+// This function is thread-safe
+func Stats() StatsSnapshot {
+	return StatsSnapshot{
+		TotalSpawned:     atomic.LoadUint64(&statsSpawned),
+		TotalPanics:      atomic.LoadUint64(&statsPanics),
+		ActiveGoroutines: atomic.LoadInt64(&statsActive),
+	}
+}
+
+// ResetStats atomically zeroes the cumulative counters and returns their values before the reset
+// This supports snapshot-and-reset metric collection over fixed intervals
+// ActiveGoroutines is not reset since it reflects live state rather than a cumulative count
+// This function is thread-safe
+func ResetStats() StatsSnapshot {
+	return StatsSnapshot{
+		TotalSpawned:     atomic.SwapUint64(&statsSpawned, 0),
+		TotalPanics:      atomic.SwapUint64(&statsPanics, 0),
+		ActiveGoroutines: atomic.LoadInt64(&statsActive),
+	}
+}
+
+// countPanic records a recovered panic, attributing it to a package using its stack if known
+func countPanic(stack []byte) {
+	atomic.AddUint64(&statsPanics, 1)
+	countPanicPackage(stack)
+	recordPanicTime()
+	profilePanic()
+	markPanic()
+}
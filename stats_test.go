@@ -0,0 +1,83 @@
+package tsafe
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStats(t *testing.T) {
+	t.Run("should count spawned goroutines and panics", func(t *testing.T) {
+		originalLogger := getLogger()
+		SetLogger(&mockLogger{})
+		defer SetLogger(originalLogger)
+
+		before := Stats()
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			i := i
+			GoWithRecover(func() {
+				if i%2 == 0 {
+					defer wg.Done()
+					panic("counted panic")
+				}
+				wg.Done()
+			}, func(err any) {})
+		}
+		wg.Wait()
+
+		assert.Eventually(t, func() bool {
+			return Stats().TotalPanics-before.TotalPanics >= 5
+		}, 100*time.Millisecond, time.Millisecond)
+		assert.GreaterOrEqual(t, Stats().TotalSpawned-before.TotalSpawned, uint64(10))
+	})
+
+	t.Run("should track active goroutines", func(t *testing.T) {
+		before := Stats().ActiveGoroutines
+		release := make(chan struct{})
+		started := make(chan struct{})
+		Go(func() {
+			close(started)
+			<-release
+		})
+		<-started
+		assert.GreaterOrEqual(t, Stats().ActiveGoroutines, before+1)
+		close(release)
+	})
+}
+
+func TestResetStats(t *testing.T) {
+	t.Run("should zero cumulative counters and return previous values", func(t *testing.T) {
+		done := make(chan struct{})
+		GoWithRecover(func() {
+			defer close(done)
+			panic("before reset")
+		}, func(err any) {})
+		<-done
+
+		assert.Eventually(t, func() bool {
+			return Stats().TotalPanics > 0
+		}, 100*time.Millisecond, time.Millisecond)
+
+		previous := ResetStats()
+		assert.Greater(t, previous.TotalSpawned, uint64(0))
+		assert.Greater(t, previous.TotalPanics, uint64(0))
+	})
+
+	t.Run("should keep active goroutines", func(t *testing.T) {
+		release := make(chan struct{})
+		started := make(chan struct{})
+		Go(func() {
+			close(started)
+			<-release
+		})
+		<-started
+
+		ResetStats()
+		assert.GreaterOrEqual(t, Stats().ActiveGoroutines, int64(1))
+		close(release)
+	})
+}
@@ -0,0 +1,57 @@
+package tsafe
+
+import (
+	"runtime"
+	"sync/atomic"
+)
+
+// SpawnStrategy decides how GoWithRecover starts functions
+type SpawnStrategy int32
+
+const (
+	// StrategyAlwaysGoroutine starts every function in a new goroutine (the default)
+	StrategyAlwaysGoroutine SpawnStrategy = iota
+	// StrategyInlineIfIdle runs functions synchronously in the caller while fewer tsafe
+	// goroutines than the inline threshold are active
+	StrategyInlineIfIdle
+)
+
+// Spawn strategy settings
+var (
+	spawnStrategy   int32
+	inlineThreshold int64
+)
+
+// SetSpawnStrategy sets how Go and the other package-level spawners built on GoWithRecover
+// start functions
+// StrategyInlineIfIdle avoids the scheduling overhead of go for workloads with many tiny
+// tasks by running them inline when the process is lightly loaded. Recovery, logging and
+// hooks behave exactly as for a goroutine, but the caller blocks until the function returns,
+// and a function calling runtime.Goexit ends the caller's goroutine, so only use it for
+// short, non-blocking functions. Inline calls are not counted in Stats
+// This function is thread-safe
+func SetSpawnStrategy(strategy SpawnStrategy) {
+	atomic.StoreInt32(&spawnStrategy, int32(strategy))
+}
+
+// SetInlineThreshold sets the number of active tsafe goroutines below which
+// StrategyInlineIfIdle runs functions inline. A value of 0 uses runtime.GOMAXPROCS (the default)
+// This function is thread-safe
+func SetInlineThreshold(n int) {
+	if n < 0 {
+		n = 0
+	}
+	atomic.StoreInt64(&inlineThreshold, int64(n))
+}
+
+// shouldRunInline reports whether the spawn strategy asks to run the next function inline
+func shouldRunInline() bool {
+	if SpawnStrategy(atomic.LoadInt32(&spawnStrategy)) != StrategyInlineIfIdle {
+		return false
+	}
+	threshold := atomic.LoadInt64(&inlineThreshold)
+	if threshold == 0 {
+		threshold = int64(runtime.GOMAXPROCS(0))
+	}
+	return atomic.LoadInt64(&statsActive) < threshold
+}
@@ -0,0 +1,58 @@
+package tsafe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetSpawnStrategy(t *testing.T) {
+	t.Run("should run inline while idle", func(t *testing.T) {
+		waitForNoActiveGoroutines(t)
+		SetSpawnStrategy(StrategyInlineIfIdle)
+		defer SetSpawnStrategy(StrategyAlwaysGoroutine)
+		SetInlineThreshold(1)
+		defer SetInlineThreshold(0)
+
+		ran := false
+		Go(func() { ran = true })
+		assert.True(t, ran)
+	})
+
+	t.Run("should spawn when the threshold is reached", func(t *testing.T) {
+		waitForNoActiveGoroutines(t)
+		release := make(chan struct{})
+		defer close(release)
+		GoSilent(func() { <-release })
+
+		SetSpawnStrategy(StrategyInlineIfIdle)
+		defer SetSpawnStrategy(StrategyAlwaysGoroutine)
+		SetInlineThreshold(1)
+		defer SetInlineThreshold(0)
+
+		// Running inline would block on release forever
+		done := GoDone(func() { <-release })
+		select {
+		case <-done:
+			t.Fatal("function finished before release")
+		default:
+		}
+	})
+
+	t.Run("should recover inline panics like spawned ones", func(t *testing.T) {
+		logger := &mockLogger{}
+		SetLogger(logger)
+		defer SetLogger(&defaultLoggerImpl{})
+		waitForNoActiveGoroutines(t)
+		SetSpawnStrategy(StrategyInlineIfIdle)
+		defer SetSpawnStrategy(StrategyAlwaysGoroutine)
+		SetInlineThreshold(1)
+		defer SetInlineThreshold(0)
+
+		assert.NotPanics(t, func() {
+			Go(func() { panic("inline panic") })
+		})
+		assert.Equal(t, 1, logger.getCallCount())
+		assert.Equal(t, "inline panic", logger.getLastError())
+	})
+}
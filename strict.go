@@ -0,0 +1,46 @@
+package tsafe
+
+import (
+	"errors"
+	"runtime/debug"
+	"sync/atomic"
+)
+
+// ErrSpawnBeforeStart is logged as a warning in strict mode when a goroutine is started before MarkStarted
+var ErrSpawnBeforeStart = errors.New("tsafe: goroutine started before MarkStarted, possibly during init")
+
+// Strict mode state, accessed atomically
+var (
+	strictMode int32
+	started    int32
+)
+
+// SetStrictMode enables or disables strict mode, a debug aid catching initialization-order
+// bugs: while it is on, every goroutine started through GoWithRecover (and therefore Go and
+// the other package-level spawners) before MarkStarted was called logs ErrSpawnBeforeStart
+// as a warning with the spawn-site stack. The goroutine is still started
+// Strict mode is off by default, and on by default in binaries built with the tsafe_strict
+// build tag. When off, the check costs a single atomic load
+// This function is thread-safe
+func SetStrictMode(on bool) {
+	var v int32
+	if on {
+		v = 1
+	}
+	atomic.StoreInt32(&strictMode, v)
+}
+
+// MarkStarted records that initialization is over; call it at the top of main
+// In strict mode, goroutines started before it are reported, see SetStrictMode
+// This function is thread-safe
+func MarkStarted() {
+	atomic.StoreInt32(&started, 1)
+}
+
+// checkSpawnBeforeStart warns about a spawn before MarkStarted in strict mode
+func checkSpawnBeforeStart() {
+	if atomic.LoadInt32(&strictMode) == 0 || atomic.LoadInt32(&started) == 1 {
+		return
+	}
+	logWarning(ErrSpawnBeforeStart, debug.Stack())
+}
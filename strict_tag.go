@@ -0,0 +1,7 @@
+//go:build tsafe_strict
+
+package tsafe
+
+func init() {
+	strictMode = 1
+}
@@ -0,0 +1,55 @@
+package tsafe
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStrictMode(t *testing.T) {
+	defer SetStrictMode(false)
+	originalStarted := atomic.LoadInt32(&started)
+	defer atomic.StoreInt32(&started, originalStarted)
+
+	t.Run("should warn about spawns before MarkStarted", func(t *testing.T) {
+		mock := &mockLogger{}
+		SetLogger(mock)
+		defer SetLogger(&defaultLoggerImpl{})
+		atomic.StoreInt32(&started, 0)
+		SetStrictMode(true)
+
+		done := make(chan struct{})
+		Go(func() { close(done) })
+		<-done
+		assert.Equal(t, 1, mock.getCallCount())
+		assert.Equal(t, ErrSpawnBeforeStart, mock.getLastError())
+	})
+
+	t.Run("should not warn after MarkStarted", func(t *testing.T) {
+		mock := &mockLogger{}
+		SetLogger(mock)
+		defer SetLogger(&defaultLoggerImpl{})
+		atomic.StoreInt32(&started, 0)
+		SetStrictMode(true)
+		MarkStarted()
+
+		done := make(chan struct{})
+		Go(func() { close(done) })
+		<-done
+		assert.Equal(t, 0, mock.getCallCount())
+	})
+
+	t.Run("should not warn outside strict mode", func(t *testing.T) {
+		mock := &mockLogger{}
+		SetLogger(mock)
+		defer SetLogger(&defaultLoggerImpl{})
+		atomic.StoreInt32(&started, 0)
+		SetStrictMode(false)
+
+		done := make(chan struct{})
+		Go(func() { close(done) })
+		<-done
+		assert.Equal(t, 0, mock.getCallCount())
+	})
+}
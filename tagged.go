@@ -0,0 +1,128 @@
+package tsafe
+
+import (
+	"context"
+	"runtime/debug"
+	"sync"
+)
+
+// tagField is the field key carrying the tag of a goroutine in panic reports
+const tagField = "tag"
+
+// tagEntry is the shared context of the running goroutines of one tag
+type tagEntry struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	refs   int // goroutines still running with ctx
+}
+
+// Thread-safe tag registry
+var (
+	tags      = make(map[string]*tagEntry)
+	tagsMutex sync.Mutex
+)
+
+// Thread-safe per-tag concurrency limits
+var (
+	tagLimits      = make(map[string]*Semaphore)
+	tagLimitsMutex sync.RWMutex
+)
+
+// SetTagLimit caps the number of goroutines of tag started with GoTagged that run at once,
+// independently of other tags, e.g. to throttle "email" more than "thumbnail"
+// GoTagged blocks the caller while the limit is reached, until a goroutine of the tag finishes
+// A non-positive max removes the limit. Goroutines already running keep counting against the
+// limit they were started under
+// This function is thread-safe
+func SetTagLimit(tag string, max int) {
+	tagLimitsMutex.Lock()
+	defer tagLimitsMutex.Unlock()
+	if max <= 0 {
+		delete(tagLimits, tag)
+		return
+	}
+	tagLimits[tag] = NewSemaphore(max)
+}
+
+// getTagLimit returns the semaphore limiting tag, or nil if it is unlimited
+func getTagLimit(tag string) *Semaphore {
+	tagLimitsMutex.RLock()
+	defer tagLimitsMutex.RUnlock()
+	return tagLimits[tag]
+}
+
+// GoTagged starts a goroutine with automatic panic recovery that belongs to the logical
+// operation tag, such as a user session
+// goroutine receives a context canceled by CancelTag(tag), so every goroutine of the tag can
+// be stopped at once. The tag is included in the panic report as the "tag" field
+// A tag is forgotten once its last goroutine finishes. See SetTagLimit to limit its concurrency
+func GoTagged(tag string, goroutine func(ctx context.Context)) {
+	if goroutine == nil {
+		return // Avoid creating goroutine for nil function
+	}
+	limit := getTagLimit(tag)
+	if limit != nil {
+		_ = limit.Acquire(context.Background())
+	}
+	// The reference is taken before spawning so a CancelTag right after GoTagged reaches it
+	entry := acquireTag(tag)
+	var once sync.Once
+	release := func() {
+		once.Do(func() {
+			releaseTag(tag, entry)
+			if limit != nil {
+				limit.Release()
+			}
+		})
+	}
+
+	fields := map[string]any{tagField: tag}
+	goWithRecover(trackGoroutine("", tag, func() {
+		defer release() // Runs on panic too, before the panic is reported
+		goroutine(entry.ctx)
+	}), func(err any) {
+		reportPanic(err, debug.Stack(), fields)
+	}, release) // Also released when the function never runs, e.g. dropped after shutdown
+}
+
+// CancelTag cancels the context of every running goroutine started with tag
+// Goroutines started with the same tag afterwards get a new, uncanceled context
+// This function is thread-safe
+func CancelTag(tag string) {
+	tagsMutex.Lock()
+	entry, ok := tags[tag]
+	if ok {
+		delete(tags, tag)
+	}
+	tagsMutex.Unlock()
+	if ok {
+		entry.cancel()
+	}
+}
+
+// acquireTag takes a reference on the entry of tag, registering it if needed
+func acquireTag(tag string) *tagEntry {
+	tagsMutex.Lock()
+	defer tagsMutex.Unlock()
+	entry, ok := tags[tag]
+	if !ok {
+		ctx, cancel := context.WithCancel(context.Background())
+		entry = &tagEntry{ctx: ctx, cancel: cancel}
+		tags[tag] = entry
+	}
+	entry.refs++
+	return entry
+}
+
+// releaseTag drops a finished goroutine's reference, removing the tag after the last one
+func releaseTag(tag string, entry *tagEntry) {
+	tagsMutex.Lock()
+	defer tagsMutex.Unlock()
+	if entry.refs--; entry.refs > 0 {
+		return
+	}
+	if tags[tag] == entry {
+		delete(tags, tag)
+	}
+	entry.cancel()
+}
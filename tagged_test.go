@@ -0,0 +1,174 @@
+package tsafe
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// tagCount returns the number of registered tags
+func tagCount() int {
+	tagsMutex.Lock()
+	defer tagsMutex.Unlock()
+	return len(tags)
+}
+
+func TestGoTagged(t *testing.T) {
+	t.Run("should cancel every goroutine of a tag", func(t *testing.T) {
+		stopped := make(chan string, 3)
+		started := make(chan struct{}, 3)
+		for _, tag := range []string{"session-1", "session-1", "session-2"} {
+			tag := tag
+			GoTagged(tag, func(ctx context.Context) {
+				started <- struct{}{}
+				<-ctx.Done()
+				stopped <- tag
+			})
+		}
+		for i := 0; i < 3; i++ {
+			<-started
+		}
+
+		CancelTag("session-1")
+		assert.Equal(t, "session-1", <-stopped)
+		assert.Equal(t, "session-1", <-stopped)
+		select {
+		case <-stopped:
+			t.Fatal("goroutine of another tag was canceled")
+		case <-time.After(20 * time.Millisecond):
+		}
+
+		CancelTag("session-2")
+		assert.Equal(t, "session-2", <-stopped)
+		assert.Eventually(t, func() bool { return tagCount() == 0 }, time.Second, time.Millisecond)
+	})
+
+	t.Run("should cancel goroutines that have not started yet", func(t *testing.T) {
+		canceled := make(chan struct{})
+		errs := make(chan error, 50)
+		for i := 0; i < 50; i++ {
+			GoTagged("early", func(ctx context.Context) {
+				<-canceled
+				errs <- ctx.Err()
+			})
+		}
+		CancelTag("early")
+		close(canceled)
+		for i := 0; i < 50; i++ {
+			assert.Equal(t, context.Canceled, <-errs)
+		}
+	})
+
+	t.Run("should forget a tag when its goroutines finish", func(t *testing.T) {
+		done := make(chan context.Context, 1)
+		GoTagged("short", func(ctx context.Context) { done <- ctx })
+		ctx := <-done
+		assert.Eventually(t, func() bool { return tagCount() == 0 }, time.Second, time.Millisecond)
+		assert.Error(t, ctx.Err())
+	})
+
+	t.Run("should report the tag with panics", func(t *testing.T) {
+		mock := &mockFieldLogger{}
+		SetLogger(mock)
+		defer SetLogger(&defaultLoggerImpl{})
+
+		GoTagged("broken", func(ctx context.Context) { panic("tagged panic") })
+		assert.Eventually(t, func() bool {
+			calls, _ := mock.getFieldCalls()
+			return calls == 1
+		}, time.Second, time.Millisecond)
+		_, fields := mock.getFieldCalls()
+		assert.Equal(t, map[string]any{"tag": "broken"}, fields)
+		assert.Eventually(t, func() bool { return tagCount() == 0 }, time.Second, time.Millisecond)
+	})
+
+	t.Run("should not register a tag when dropped after shutdown", func(t *testing.T) {
+		SetLogger(&mockLogger{})
+		defer SetLogger(&defaultLoggerImpl{})
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		SetShutdownContext(ctx)
+		defer SetShutdownContext(nil)
+
+		GoTagged("late", func(ctx context.Context) {})
+		assert.Equal(t, 0, tagCount())
+	})
+}
+
+func TestSetTagLimit(t *testing.T) {
+	t.Run("should cap concurrent goroutines per tag", func(t *testing.T) {
+		SetTagLimit("email", 2)
+		defer SetTagLimit("email", 0)
+
+		var running, peak int32
+		release := make(chan struct{})
+		var wg sync.WaitGroup
+		started := make(chan struct{})
+		go func() {
+			defer close(started)
+			for i := 0; i < 6; i++ {
+				wg.Add(1)
+				GoTagged("email", func(ctx context.Context) {
+					defer wg.Done()
+					n := atomic.AddInt32(&running, 1)
+					for {
+						p := atomic.LoadInt32(&peak)
+						if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+							break
+						}
+					}
+					<-release
+					atomic.AddInt32(&running, -1)
+				})
+			}
+		}()
+
+		assert.Eventually(t, func() bool { return atomic.LoadInt32(&running) == 2 }, time.Second, time.Millisecond)
+		// Other tags are not limited by the email budget
+		other := make(chan struct{})
+		GoTagged("thumbnail", func(ctx context.Context) { close(other) })
+		<-other
+
+		close(release)
+		<-started
+		wg.Wait()
+		assert.Equal(t, int32(2), atomic.LoadInt32(&peak))
+	})
+
+	t.Run("should release the slot when a goroutine panics", func(t *testing.T) {
+		SetLogger(&mockLogger{})
+		defer SetLogger(&defaultLoggerImpl{})
+		SetTagLimit("fragile", 1)
+		defer SetTagLimit("fragile", 0)
+
+		GoTagged("fragile", func(ctx context.Context) { panic("tagged panic") })
+		done := make(chan struct{})
+		GoTagged("fragile", func(ctx context.Context) { close(done) })
+		<-done
+	})
+
+	t.Run("should release the slot when dropped after shutdown", func(t *testing.T) {
+		SetLogger(&mockLogger{})
+		defer SetLogger(&defaultLoggerImpl{})
+		SetTagLimit("late", 1)
+		defer SetTagLimit("late", 0)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		SetShutdownContext(ctx)
+		GoTagged("late", func(ctx context.Context) {})
+		SetShutdownContext(nil)
+
+		done := make(chan struct{})
+		go GoTagged("late", func(ctx context.Context) { close(done) })
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("slot leaked by the dropped spawn")
+		}
+	})
+}
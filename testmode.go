@@ -0,0 +1,43 @@
+package tsafe
+
+import "sync/atomic"
+
+// Test mode state, accessed atomically
+var (
+	testMode int32
+	hadPanic int32
+)
+
+func init() {
+	if defaultTestMode() {
+		testMode = 1
+	}
+}
+
+// SetTestMode enables or disables test mode, where every recovered panic also sets a global
+// flag reported by HadPanic, so a TestMain can fail the suite when a background goroutine
+// panicked even though the panic was recovered
+// Test mode is enabled by default when running under go test on Go 1.21 and later, and is off
+// otherwise. Calling SetTestMode also clears the flag
+// This function is thread-safe
+func SetTestMode(on bool) {
+	var v int32
+	if on {
+		v = 1
+	}
+	atomic.StoreInt32(&testMode, v)
+	atomic.StoreInt32(&hadPanic, 0)
+}
+
+// HadPanic reports whether a panic was recovered while test mode was enabled
+// This function is thread-safe
+func HadPanic() bool {
+	return atomic.LoadInt32(&hadPanic) == 1
+}
+
+// markPanic sets the HadPanic flag if test mode is enabled
+func markPanic() {
+	if atomic.LoadInt32(&testMode) == 1 {
+		atomic.StoreInt32(&hadPanic, 1)
+	}
+}
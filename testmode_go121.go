@@ -0,0 +1,10 @@
+//go:build go1.21
+
+package tsafe
+
+import "testing"
+
+// defaultTestMode reports whether the process is a test binary
+func defaultTestMode() bool {
+	return testing.Testing()
+}
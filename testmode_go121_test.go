@@ -0,0 +1,15 @@
+//go:build go1.21
+
+package tsafe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTestModeDefault(t *testing.T) {
+	t.Run("should start enabled under go test", func(t *testing.T) {
+		assert.True(t, defaultTestMode())
+	})
+}
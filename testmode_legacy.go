@@ -0,0 +1,9 @@
+//go:build !go1.21
+
+package tsafe
+
+// defaultTestMode reports whether test mode starts enabled
+// testing.Testing is unavailable before Go 1.21, so test mode has to be enabled explicitly
+func defaultTestMode() bool {
+	return false
+}
@@ -0,0 +1,37 @@
+package tsafe
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTestMode(t *testing.T) {
+	defer SetTestMode(defaultTestMode())
+
+	t.Run("should flag recovered panics in test mode", func(t *testing.T) {
+		SetTestMode(true)
+		assert.False(t, HadPanic())
+
+		GoSilent(func() { panic("hidden") })
+		assert.Eventually(t, HadPanic, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("should not flag panics outside test mode", func(t *testing.T) {
+		SetTestMode(false)
+		done := make(chan struct{})
+		GoWithRecover(func() { panic("hidden") }, func(err any) { close(done) })
+		<-done
+		assert.False(t, HadPanic())
+	})
+
+	t.Run("should clear the flag when the mode is set", func(t *testing.T) {
+		SetTestMode(true)
+		_ = WrapErr(func() error { panic("hidden") })()
+		assert.True(t, HadPanic())
+
+		SetTestMode(true)
+		assert.False(t, HadPanic())
+	})
+}
@@ -0,0 +1,62 @@
+package tsafe
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Global goroutine throttle state
+var (
+	maxGlobalGoroutines int64
+	throttleMutex       sync.Mutex
+	throttleCond        = sync.NewCond(&throttleMutex)
+)
+
+// SetMaxGlobalGoroutines limits how many tsafe goroutines may be active before Go blocks
+// When n goroutines are active, Go and the other package-level spawners built on
+// GoWithRecover (GoNamed, GoWithFields, GoLabeled, GoCritical) block the caller until one
+// finishes, including by panicking. Goroutines run by pools, groups and the other helpers
+// count towards the limit but are never blocked by it. A value of 0 means unlimited (the default)
+// A tsafe goroutine that spawns while the limit is reached waits while holding its own slot,
+// so deeply nested spawning can deadlock under a low limit
+// This function is thread-safe
+func SetMaxGlobalGoroutines(n int) {
+	if n < 0 {
+		n = 0
+	}
+	throttleMutex.Lock()
+	defer throttleMutex.Unlock()
+	atomic.StoreInt64(&maxGlobalGoroutines, int64(n))
+	throttleCond.Broadcast()
+}
+
+// spawnThrottled waits for the global limit to allow another goroutine and then spawns fn
+func spawnThrottled(fn func()) {
+	if atomic.LoadInt64(&maxGlobalGoroutines) == 0 {
+		spawn(fn)
+		return
+	}
+
+	throttleMutex.Lock()
+	for {
+		limit := atomic.LoadInt64(&maxGlobalGoroutines)
+		if limit == 0 || atomic.LoadInt64(&statsActive) < limit {
+			break
+		}
+		throttleCond.Wait()
+	}
+	trackSpawn()
+	throttleMutex.Unlock()
+	// Scheduled outside the lock so a scheduler running fn inline cannot deadlock
+	schedule(fn)
+}
+
+// releaseGlobalSlot marks a tsafe goroutine as finished and wakes callers blocked by the limit
+func releaseGlobalSlot() {
+	atomic.AddInt64(&statsActive, -1)
+	if atomic.LoadInt64(&maxGlobalGoroutines) > 0 {
+		throttleMutex.Lock()
+		throttleCond.Broadcast()
+		throttleMutex.Unlock()
+	}
+}
@@ -0,0 +1,99 @@
+package tsafe
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// waitForNoActiveGoroutines waits for goroutines left over by earlier tests to finish
+func waitForNoActiveGoroutines(t *testing.T) {
+	assert.Eventually(t, func() bool {
+		return Stats().ActiveGoroutines == 0
+	}, time.Second, time.Millisecond)
+}
+
+func TestSetMaxGlobalGoroutines(t *testing.T) {
+	t.Run("should block Go while the limit is reached", func(t *testing.T) {
+		waitForNoActiveGoroutines(t)
+		SetMaxGlobalGoroutines(2)
+		defer SetMaxGlobalGoroutines(0)
+
+		release := make(chan struct{})
+		for i := 0; i < 2; i++ {
+			Go(func() { <-release })
+		}
+
+		var started int32
+		spawned := make(chan struct{})
+		go func() {
+			Go(func() { atomic.AddInt32(&started, 1) })
+			close(spawned)
+		}()
+
+		select {
+		case <-spawned:
+			t.Fatal("Go did not block at the limit")
+		case <-time.After(20 * time.Millisecond):
+		}
+
+		close(release)
+		select {
+		case <-spawned:
+		case <-time.After(time.Second):
+			t.Fatal("Go stayed blocked after slots were released")
+		}
+		assert.Eventually(t, func() bool {
+			return atomic.LoadInt32(&started) == 1
+		}, 100*time.Millisecond, time.Millisecond)
+	})
+
+	t.Run("should release slots on panic", func(t *testing.T) {
+		originalLogger := getLogger()
+		SetLogger(&mockLogger{})
+		defer SetLogger(originalLogger)
+
+		waitForNoActiveGoroutines(t)
+		SetMaxGlobalGoroutines(1)
+		defer SetMaxGlobalGoroutines(0)
+
+		done := make(chan struct{})
+		go func() {
+			for i := 0; i < 5; i++ {
+				Go(func() { panic("throttled panic") })
+			}
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("panicking goroutines did not release their slots")
+		}
+	})
+
+	t.Run("should unblock waiters when the limit is removed", func(t *testing.T) {
+		waitForNoActiveGoroutines(t)
+		SetMaxGlobalGoroutines(1)
+
+		release := make(chan struct{})
+		defer close(release)
+		Go(func() { <-release })
+
+		spawned := make(chan struct{})
+		go func() {
+			Go(func() {})
+			close(spawned)
+		}()
+
+		time.Sleep(10 * time.Millisecond)
+		SetMaxGlobalGoroutines(0)
+		select {
+		case <-spawned:
+		case <-time.After(time.Second):
+			t.Fatal("Go stayed blocked after the limit was removed")
+		}
+	})
+}
@@ -0,0 +1,38 @@
+package tsafe
+
+import "time"
+
+// GoTimeoutOr runs fn in a recovered goroutine and returns its result if it completes within d,
+// otherwise fallback. If fn panics, the panic is logged with the configured logger and
+// fallback is returned
+// On timeout fn is abandoned rather than stopped: it keeps running with recovery intact,
+// and its result is discarded once it returns, so the goroutine never leaks blocked on a send
+func GoTimeoutOr[T any](d time.Duration, fn func() T, fallback T) T {
+	if fn == nil {
+		return fallback
+	}
+
+	// Buffered so an abandoned goroutine can always deliver its result and exit
+	result := make(chan T, 1)
+	failed := make(chan struct{})
+	spawn(func() {
+		var v T
+		if pe := runRecovered(func() { v = fn() }); pe != nil {
+			reportPanic(pe.Value, pe.Stack, nil)
+			close(failed)
+			return
+		}
+		result <- v
+	})
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case v := <-result:
+		return v
+	case <-failed:
+		return fallback
+	case <-timer.C:
+		return fallback
+	}
+}
@@ -0,0 +1,43 @@
+package tsafe
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoTimeoutOr(t *testing.T) {
+	t.Run("should return the result when fn completes in time", func(t *testing.T) {
+		v := GoTimeoutOr(time.Second, func() int { return 42 }, -1)
+		assert.Equal(t, 42, v)
+	})
+
+	t.Run("should return the fallback on timeout", func(t *testing.T) {
+		release := make(chan struct{})
+		v := GoTimeoutOr(10*time.Millisecond, func() string {
+			<-release
+			return "late"
+		}, "default")
+		assert.Equal(t, "default", v)
+
+		close(release)
+		waitForNoActiveGoroutines(t)
+	})
+
+	t.Run("should return the fallback and log when fn panics", func(t *testing.T) {
+		mock := &mockLogger{}
+		SetLogger(mock)
+		defer SetLogger(&defaultLoggerImpl{})
+
+		start := time.Now()
+		v := GoTimeoutOr(time.Second, func() int { panic("boom") }, 7)
+		assert.Equal(t, 7, v)
+		assert.Less(t, time.Since(start), time.Second)
+		assert.Equal(t, 1, mock.getCallCount())
+	})
+
+	t.Run("should return the fallback for a nil function", func(t *testing.T) {
+		assert.Equal(t, 3, GoTimeoutOr[int](time.Second, nil, 3))
+	})
+}
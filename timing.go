@@ -0,0 +1,92 @@
+package tsafe
+
+import (
+	"math/bits"
+	"sync/atomic"
+	"time"
+)
+
+// numDurationBuckets is the number of histogram buckets; bucket i holds durations below 1µs<<i
+// and the last one everything longer
+const numDurationBuckets = 40
+
+// Goroutine lifetime timing state
+var (
+	timingEnabled int32
+	durationMax   int64 // nanoseconds
+	durationHist  [numDurationBuckets]uint64
+)
+
+// EnableTiming turns recording of the lifetime of every tsafe goroutine on or off
+// When enabled, each goroutine reads the clock when it starts and when it finishes and feeds
+// the duration into the histogram behind DurationStats. It is off by default
+// This function is thread-safe
+func EnableTiming(enabled bool) {
+	var v int32
+	if enabled {
+		v = 1
+	}
+	atomic.StoreInt32(&timingEnabled, v)
+}
+
+// DurationStats summarizes the recorded goroutine lifetimes
+// The percentiles are approximations from a histogram with power-of-two buckets starting at
+// 1µs, so they are accurate to within a factor of two; max is exact
+// This function is thread-safe
+func DurationStats() (count uint64, p50, p95, max time.Duration) {
+	var buckets [numDurationBuckets]uint64
+	for i := range buckets {
+		buckets[i] = atomic.LoadUint64(&durationHist[i])
+		count += buckets[i]
+	}
+	max = time.Duration(atomic.LoadInt64(&durationMax))
+	return count, durationPercentile(buckets, count, 0.50, max), durationPercentile(buckets, count, 0.95, max), max
+}
+
+// durationPercentile returns the upper bound of the bucket holding the p-th percentile, capped at max
+func durationPercentile(buckets [numDurationBuckets]uint64, count uint64, p float64, max time.Duration) time.Duration {
+	if count == 0 {
+		return 0
+	}
+	rank := uint64(p*float64(count) + 0.5)
+	if rank == 0 {
+		rank = 1
+	}
+	var seen uint64
+	for i, n := range buckets {
+		if seen += n; seen >= rank {
+			if bound := time.Microsecond << uint(i); bound < max && i < numDurationBuckets-1 {
+				return bound
+			}
+			break
+		}
+	}
+	return max
+}
+
+// timingStart returns the start time of a goroutine, or the zero time if timing is disabled
+func timingStart() time.Time {
+	if atomic.LoadInt32(&timingEnabled) == 0 {
+		return time.Time{}
+	}
+	return time.Now()
+}
+
+// recordDuration feeds the lifetime of a goroutine started at start into the histogram
+func recordDuration(start time.Time) {
+	if start.IsZero() {
+		return
+	}
+	d := time.Since(start)
+	i := bits.Len64(uint64(d / time.Microsecond))
+	if i >= numDurationBuckets {
+		i = numDurationBuckets - 1
+	}
+	atomic.AddUint64(&durationHist[i], 1)
+	for {
+		current := atomic.LoadInt64(&durationMax)
+		if int64(d) <= current || atomic.CompareAndSwapInt64(&durationMax, current, int64(d)) {
+			return
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package tsafe
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDurationStats(t *testing.T) {
+	t.Run("should record goroutine lifetimes when enabled", func(t *testing.T) {
+		EnableTiming(true)
+		defer EnableTiming(false)
+
+		before, _, _, _ := DurationStats()
+		for i := 0; i < 3; i++ {
+			<-GoDone(func() { time.Sleep(2 * time.Millisecond) })
+		}
+
+		assert.Eventually(t, func() bool {
+			count, _, _, _ := DurationStats()
+			return count >= before+3
+		}, time.Second, time.Millisecond)
+		_, p50, p95, max := DurationStats()
+		assert.GreaterOrEqual(t, int64(max), int64(2*time.Millisecond))
+		assert.LessOrEqual(t, int64(p50), int64(p95))
+		assert.LessOrEqual(t, int64(p95), int64(max))
+	})
+
+	t.Run("should not record when disabled", func(t *testing.T) {
+		waitForNoActiveGoroutines(t)
+		before, _, _, _ := DurationStats()
+		<-GoDone(func() {})
+		waitForNoActiveGoroutines(t)
+		after, _, _, _ := DurationStats()
+		assert.Equal(t, before, after)
+	})
+
+	t.Run("should approximate percentiles from the histogram", func(t *testing.T) {
+		var buckets [numDurationBuckets]uint64
+		buckets[1] = 90 // below 2µs
+		buckets[10] = 10
+		assert.Equal(t, 2*time.Microsecond, durationPercentile(buckets, 100, 0.50, time.Millisecond))
+		assert.Equal(t, time.Millisecond, durationPercentile(buckets, 100, 0.95, time.Millisecond))
+		assert.Equal(t, time.Duration(0), durationPercentile(buckets, 0, 0.50, 0))
+	})
+}
@@ -0,0 +1,80 @@
+package tsafe
+
+import (
+	"runtime/debug"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// GoroutineInfo describes a live goroutine started by GoNamed, GoNamedWithRecover or GoTagged
+type GoroutineInfo struct {
+	// Name is the name given to GoNamed or GoNamedWithRecover, or empty
+	Name string
+	// Tag is the tag given to GoTagged, or empty
+	Tag string
+	// Started is when the goroutine started running
+	Started time.Time
+	// SpawnStack is the stack of the call that started the goroutine
+	SpawnStack []byte
+}
+
+// Thread-safe registry of tracked goroutines
+var (
+	trackingEnabled int32
+	trackedNext     uint64
+	tracked         = make(map[uint64]*GoroutineInfo)
+	trackedMutex    sync.Mutex
+)
+
+// SetGoroutineTracking enables or disables the registry behind ActiveGoroutines
+// Tracking is off by default because it captures a stack for every named or tagged goroutine
+// Only goroutines started while it is enabled are tracked
+// This function is thread-safe
+func SetGoroutineTracking(enabled bool) {
+	var v int32
+	if enabled {
+		v = 1
+	}
+	atomic.StoreInt32(&trackingEnabled, v)
+}
+
+// ActiveGoroutines returns the live tracked goroutines ordered by start time, e.g. for a debug endpoint
+// The result is empty unless SetGoroutineTracking is enabled
+// This function is thread-safe
+func ActiveGoroutines() []GoroutineInfo {
+	trackedMutex.Lock()
+	infos := make([]GoroutineInfo, 0, len(tracked))
+	for _, info := range tracked {
+		infos = append(infos, *info)
+	}
+	trackedMutex.Unlock()
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Started.Before(infos[j].Started)
+	})
+	return infos
+}
+
+// trackGoroutine wraps goroutine so it is registered while it runs, if tracking is enabled
+// It must be called by the spawning goroutine so the spawn-site stack can be captured
+func trackGoroutine(name, tag string, goroutine func()) func() {
+	if goroutine == nil || atomic.LoadInt32(&trackingEnabled) == 0 {
+		return goroutine
+	}
+	spawnStack := debug.Stack()
+	return func() {
+		id := atomic.AddUint64(&trackedNext, 1)
+		trackedMutex.Lock()
+		tracked[id] = &GoroutineInfo{Name: name, Tag: tag, Started: time.Now(), SpawnStack: spawnStack}
+		trackedMutex.Unlock()
+
+		defer func() {
+			trackedMutex.Lock()
+			delete(tracked, id)
+			trackedMutex.Unlock()
+		}()
+		goroutine()
+	}
+}
@@ -0,0 +1,53 @@
+package tsafe
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActiveGoroutines(t *testing.T) {
+	t.Run("should list live named and tagged goroutines", func(t *testing.T) {
+		SetGoroutineTracking(true)
+		defer SetGoroutineTracking(false)
+
+		release := make(chan struct{})
+		GoNamed("indexer", func() { <-release })
+		GoTagged("session-9", func(ctx context.Context) { <-release })
+
+		assert.Eventually(t, func() bool { return len(ActiveGoroutines()) == 2 }, time.Second, time.Millisecond)
+		var names, tags []string
+		for _, info := range ActiveGoroutines() {
+			names = append(names, info.Name)
+			tags = append(tags, info.Tag)
+			assert.False(t, info.Started.IsZero())
+			assert.True(t, strings.Contains(string(info.SpawnStack), "TestActiveGoroutines"))
+		}
+		assert.ElementsMatch(t, []string{"indexer", ""}, names)
+		assert.ElementsMatch(t, []string{"", "session-9"}, tags)
+
+		close(release)
+		assert.Eventually(t, func() bool { return len(ActiveGoroutines()) == 0 }, time.Second, time.Millisecond)
+	})
+
+	t.Run("should deregister panicking goroutines", func(t *testing.T) {
+		SetLogger(&mockLogger{})
+		defer SetLogger(&defaultLoggerImpl{})
+		SetGoroutineTracking(true)
+		defer SetGoroutineTracking(false)
+
+		GoNamed("broken", func() { panic("tracked panic") })
+		assert.Eventually(t, func() bool { return len(ActiveGoroutines()) == 0 }, time.Second, time.Millisecond)
+	})
+
+	t.Run("should not track when disabled", func(t *testing.T) {
+		release := make(chan struct{})
+		defer close(release)
+		GoNamed("untracked", func() { <-release })
+		time.Sleep(5 * time.Millisecond)
+		assert.Empty(t, ActiveGoroutines())
+	})
+}
@@ -0,0 +1,43 @@
+package tsafe
+
+import (
+	"fmt"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+// maxMessageLength is the maximum size in bytes of a logged panic value; 0 disables truncation
+var maxMessageLength int64
+
+// ellipsis marks a truncated panic message
+const ellipsis = "..."
+
+// SetMaxMessageLength limits the string form of logged panic values to n bytes
+// Longer values are passed to the logger as a string cut to n bytes followed by an ellipsis;
+// shorter values are passed unchanged. A non-positive n disables truncation (the default)
+// Stacks are not affected
+// This function is thread-safe
+func SetMaxMessageLength(n int) {
+	if n < 0 {
+		n = 0
+	}
+	atomic.StoreInt64(&maxMessageLength, int64(n))
+}
+
+// truncateValue applies the configured message length limit to a panic value
+func truncateValue(value any) any {
+	limit := int(atomic.LoadInt64(&maxMessageLength))
+	if limit == 0 {
+		return value
+	}
+	msg := fmt.Sprint(value)
+	if len(msg) <= limit {
+		return value
+	}
+	// Avoid cutting a multi-byte character in half
+	cut := limit
+	for cut > 0 && !utf8.RuneStart(msg[cut]) {
+		cut--
+	}
+	return msg[:cut] + ellipsis
+}
@@ -0,0 +1,49 @@
+package tsafe
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetMaxMessageLength(t *testing.T) {
+	t.Run("should truncate long panic values before logging", func(t *testing.T) {
+		mock := &mockLogger{}
+		originalLogger := getLogger()
+		SetLogger(mock)
+		defer SetLogger(originalLogger)
+
+		SetMaxMessageLength(10)
+		defer SetMaxMessageLength(0)
+
+		Go(func() {
+			panic(strings.Repeat("x", 1000))
+		})
+		assert.Eventually(t, func() bool {
+			return mock.getCallCount() == 1
+		}, 100*time.Millisecond, time.Millisecond)
+		assert.Equal(t, "xxxxxxxxxx...", mock.getLastError())
+	})
+
+	t.Run("should pass short values unchanged", func(t *testing.T) {
+		SetMaxMessageLength(10)
+		defer SetMaxMessageLength(0)
+		assert.Equal(t, 42, truncateValue(42))
+		assert.Equal(t, "short", truncateValue("short"))
+	})
+
+	t.Run("should not split multi-byte characters", func(t *testing.T) {
+		SetMaxMessageLength(4)
+		defer SetMaxMessageLength(0)
+		// "日" is 3 bytes, so the 4 byte limit keeps only the first character
+		assert.Equal(t, "日...", truncateValue("日本語"))
+	})
+
+	t.Run("should not truncate when disabled", func(t *testing.T) {
+		SetMaxMessageLength(-1)
+		long := strings.Repeat("y", 100)
+		assert.Equal(t, long, truncateValue(long))
+	})
+}
@@ -0,0 +1,19 @@
+// Package tsafeerrgroup bridges tsafe's panic recovery into golang.org/x/sync/errgroup
+// It lives in its own module so the tsafe core does not depend on x/sync
+package tsafeerrgroup
+
+import (
+	"github.com/tinystack/tsafe"
+	"golang.org/x/sync/errgroup"
+)
+
+// GoInto adds fn to eg as a recovered task
+// A panic in fn is converted to a *tsafe.PanicError that eg.Wait returns like any other
+// error, instead of crashing the process, so existing errgroup code can adopt tsafe's
+// recovery incrementally. The panic is not logged; handle the error returned by Wait
+func GoInto(eg *errgroup.Group, fn func() error) {
+	if fn == nil {
+		return // Avoid creating goroutine for nil function
+	}
+	eg.Go(tsafe.WrapErr(fn))
+}
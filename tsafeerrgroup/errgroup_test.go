@@ -0,0 +1,47 @@
+package tsafeerrgroup
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tinystack/tsafe"
+	"golang.org/x/sync/errgroup"
+)
+
+func TestGoInto(t *testing.T) {
+	t.Run("should return panics from Wait as PanicError", func(t *testing.T) {
+		var eg errgroup.Group
+		GoInto(&eg, func() error { return nil })
+		GoInto(&eg, func() error { panic("boom") })
+
+		var pe *tsafe.PanicError
+		assert.ErrorAs(t, eg.Wait(), &pe)
+		assert.Equal(t, "boom", pe.Value)
+		assert.NotEmpty(t, pe.Stack)
+	})
+
+	t.Run("should return regular errors unchanged", func(t *testing.T) {
+		sentinel := errors.New("failed")
+		var eg errgroup.Group
+		GoInto(&eg, func() error { return sentinel })
+		assert.Equal(t, sentinel, eg.Wait())
+	})
+
+	t.Run("should cancel the group context on panic", func(t *testing.T) {
+		eg, ctx := errgroup.WithContext(context.Background())
+		GoInto(eg, func() error { panic("boom") })
+		GoInto(eg, func() error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		assert.ErrorIs(t, eg.Wait(), tsafe.ErrPanic)
+	})
+
+	t.Run("should ignore nil functions", func(t *testing.T) {
+		var eg errgroup.Group
+		GoInto(&eg, nil)
+		assert.NoError(t, eg.Wait())
+	})
+}
@@ -0,0 +1,21 @@
+module github.com/tinystack/tsafe/tsafezap
+
+go 1.19
+
+// The tsafe requirement is a placeholder until the first tagged tsafe release; the replace builds against
+// the tsafe checkout this module lives in. Release step: tag the tsafe module, require that
+// version below, drop the replace and run go mod tidy before tagging this module
+replace github.com/tinystack/tsafe => ../
+
+require (
+	github.com/stretchr/testify v1.8.1
+	github.com/tinystack/tsafe v0.0.0-00010101000000-000000000000
+	go.uber.org/zap v1.28.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	go.uber.org/multierr v1.10.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
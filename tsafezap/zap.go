@@ -0,0 +1,57 @@
+// Package tsafezap adapts a zap logger to the tsafe Logger interface
+// It lives in its own module so the tsafe core does not depend on zap
+package tsafezap
+
+import (
+	"fmt"
+
+	"github.com/tinystack/tsafe"
+	"go.uber.org/zap"
+)
+
+// Logger is a tsafe.Logger and tsafe.FieldLogger writing recovered panics to a *zap.Logger
+type Logger struct {
+	zl *zap.Logger
+}
+
+// New creates a Logger writing to zl
+func New(zl *zap.Logger) *Logger {
+	return &Logger{zl: zl}
+}
+
+// Print implements the tsafe.Logger interface
+// It emits an error-level entry with the panic as the "err" field and the stack as the "stack" field
+func (l *Logger) Print(err, stack any) {
+	l.zl.Error("panic recovered in goroutine", panicFields(err, stack)...)
+}
+
+// PrintFields implements the tsafe.FieldLogger interface
+// The tsafe fields are added as zap fields next to "err" and "stack"
+func (l *Logger) PrintFields(err, stack any, fields map[string]any) {
+	zfs := panicFields(err, stack)
+	for k, v := range fields {
+		zfs = append(zfs, zap.Any(k, v))
+	}
+	l.zl.Error("panic recovered in goroutine", zfs...)
+}
+
+// panicFields renders the panic value and stack as zap fields
+func panicFields(err, stack any) []zap.Field {
+	return []zap.Field{
+		zap.String("err", fmt.Sprint(err)),
+		zap.String("stack", stackString(stack)),
+	}
+}
+
+// stackString renders a stack passed by tsafe, which is usually a []byte
+func stackString(stack any) string {
+	if b, ok := stack.([]byte); ok {
+		return string(b)
+	}
+	return fmt.Sprint(stack)
+}
+
+var (
+	_ tsafe.Logger      = (*Logger)(nil)
+	_ tsafe.FieldLogger = (*Logger)(nil)
+)
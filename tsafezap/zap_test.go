@@ -0,0 +1,35 @@
+package tsafezap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLogger(t *testing.T) {
+	t.Run("should log an error entry with err and stack fields", func(t *testing.T) {
+		core, logs := observer.New(zapcore.DebugLevel)
+		New(zap.New(core)).Print("boom", []byte("goroutine 1 [running]:"))
+
+		entries := logs.All()
+		if assert.Len(t, entries, 1) {
+			assert.Equal(t, zapcore.ErrorLevel, entries[0].Level)
+			fields := entries[0].ContextMap()
+			assert.Equal(t, "boom", fields["err"])
+			assert.Equal(t, "goroutine 1 [running]:", fields["stack"])
+		}
+	})
+
+	t.Run("should add tsafe fields", func(t *testing.T) {
+		core, logs := observer.New(zapcore.DebugLevel)
+		New(zap.New(core)).PrintFields("boom", []byte("stack"), map[string]any{"name": "worker"})
+
+		entries := logs.All()
+		if assert.Len(t, entries, 1) {
+			assert.Equal(t, "worker", entries[0].ContextMap()["name"])
+		}
+	})
+}
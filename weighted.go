@@ -0,0 +1,156 @@
+package tsafe
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrWeightExceedsCapacity is returned when a caller asks for more weight than a WeightedSemaphore holds
+var ErrWeightExceedsCapacity = errors.New("tsafe: weight exceeds semaphore capacity")
+
+// WeightedSemaphore limits the combined weight of concurrent holders of a resource
+// Tasks declare how much of the capacity they use, so expensive tasks can count for more
+// than cheap ones. Waiters are admitted in FIFO order, so a heavy task is not starved by
+// lighter ones arriving after it
+type WeightedSemaphore struct {
+	capacity int64
+	mu       sync.Mutex
+	used     int64
+	waiters  list.List // of *weightedWaiter
+}
+
+// weightedWaiter is a caller blocked in Acquire
+type weightedWaiter struct {
+	n     int64
+	ready chan struct{} // closed once the weight was granted
+}
+
+// NewWeightedSemaphore creates a semaphore with the given total capacity
+// A non-positive capacity is treated as 1
+func NewWeightedSemaphore(capacity int64) *WeightedSemaphore {
+	if capacity <= 0 {
+		capacity = 1
+	}
+	return &WeightedSemaphore{capacity: capacity}
+}
+
+// Acquire blocks until a weight of n is available or ctx is done
+// It returns ErrWeightExceedsCapacity immediately if n is larger than the capacity, and
+// ctx.Err() if the context ends first, in which case the caller holds nothing
+// A non-positive n is granted immediately
+func (s *WeightedSemaphore) Acquire(ctx context.Context, n int64) error {
+	if n <= 0 {
+		return nil
+	}
+	if n > s.capacity {
+		return ErrWeightExceedsCapacity
+	}
+
+	s.mu.Lock()
+	// Fast path: enough weight is free and nobody is queued ahead
+	if s.capacity-s.used >= n && s.waiters.Len() == 0 {
+		s.used += n
+		s.mu.Unlock()
+		return nil
+	}
+	w := &weightedWaiter{n: n, ready: make(chan struct{})}
+	elem := s.waiters.PushBack(w)
+	s.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		select {
+		case <-w.ready:
+			// Granted while the context ended; give the weight back
+			s.used -= n
+			s.notifyWaiters()
+		default:
+			front := s.waiters.Front() == elem
+			s.waiters.Remove(elem)
+			if front {
+				// Waiters behind this one may fit now
+				s.notifyWaiters()
+			}
+		}
+		s.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// TryAcquire obtains a weight of n without blocking and reports whether it succeeded
+func (s *WeightedSemaphore) TryAcquire(n int64) bool {
+	if n <= 0 {
+		return true
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.capacity-s.used >= n && s.waiters.Len() == 0 {
+		s.used += n
+		return true
+	}
+	return false
+}
+
+// Release returns a weight of n previously obtained with Acquire or TryAcquire
+// It panics if more weight is released than is held
+func (s *WeightedSemaphore) Release(n int64) {
+	if n <= 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if n > s.used {
+		panic("tsafe: weighted semaphore released more than held")
+	}
+	s.used -= n
+	s.notifyWaiters()
+}
+
+// Used returns the weight currently held
+func (s *WeightedSemaphore) Used() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.used
+}
+
+// notifyWaiters grants weight to queued waiters in order while it fits; s.mu must be held
+func (s *WeightedSemaphore) notifyWaiters() {
+	for {
+		front := s.waiters.Front()
+		if front == nil {
+			return
+		}
+		w := front.Value.(*weightedWaiter)
+		if s.capacity-s.used < w.n {
+			return
+		}
+		s.used += w.n
+		s.waiters.Remove(front)
+		close(w.ready)
+	}
+}
+
+// GoWeighted starts fn in a recovered goroutine once a weight of weight is available from sem
+// The caller blocks until the weight is acquired. It is released when fn returns, including
+// when fn panics; the panic is logged with the configured logger
+// It returns ErrWeightExceedsCapacity, without starting fn, if weight is larger than the capacity
+func GoWeighted(sem *WeightedSemaphore, weight int64, fn func()) error {
+	if fn == nil {
+		return nil // Avoid creating goroutine for nil function
+	}
+	if err := sem.Acquire(context.Background(), weight); err != nil {
+		return err
+	}
+	spawn(func() {
+		defer sem.Release(weight)
+		if pe := runRecovered(fn); pe != nil {
+			reportPanic(pe.Value, pe.Stack, nil)
+		}
+	})
+	return nil
+}
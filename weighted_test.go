@@ -0,0 +1,116 @@
+package tsafe
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWeightedSemaphore(t *testing.T) {
+	t.Run("should admit tasks until the total weight reaches the capacity", func(t *testing.T) {
+		sem := NewWeightedSemaphore(10)
+		assert.True(t, sem.TryAcquire(6))
+		assert.True(t, sem.TryAcquire(4))
+		assert.False(t, sem.TryAcquire(1))
+		assert.Equal(t, int64(10), sem.Used())
+
+		sem.Release(4)
+		assert.True(t, sem.TryAcquire(3))
+		assert.Equal(t, int64(9), sem.Used())
+	})
+
+	t.Run("should fail immediately when asking for more than the capacity", func(t *testing.T) {
+		sem := NewWeightedSemaphore(5)
+		assert.ErrorIs(t, sem.Acquire(context.Background(), 6), ErrWeightExceedsCapacity)
+		assert.Equal(t, int64(0), sem.Used())
+	})
+
+	t.Run("should unblock waiters once enough weight is released", func(t *testing.T) {
+		sem := NewWeightedSemaphore(10)
+		assert.True(t, sem.TryAcquire(8))
+
+		acquired := make(chan struct{})
+		go func() {
+			_ = sem.Acquire(context.Background(), 5)
+			close(acquired)
+		}()
+
+		sem.Release(2)
+		select {
+		case <-acquired:
+			t.Fatal("waiter admitted before enough weight was free")
+		case <-time.After(20 * time.Millisecond):
+		}
+
+		sem.Release(2)
+		<-acquired
+		assert.Equal(t, int64(9), sem.Used())
+	})
+
+	t.Run("should serve waiters in order", func(t *testing.T) {
+		sem := NewWeightedSemaphore(10)
+		assert.True(t, sem.TryAcquire(10))
+
+		heavy := make(chan struct{})
+		go func() {
+			_ = sem.Acquire(context.Background(), 10)
+			close(heavy)
+		}()
+		assert.Eventually(t, func() bool { return !sem.TryAcquire(1) }, time.Second, time.Millisecond)
+
+		// A light task must not overtake the queued heavy one
+		sem.Release(5)
+		assert.False(t, sem.TryAcquire(1))
+		sem.Release(5)
+		<-heavy
+	})
+
+	t.Run("should give up on cancellation without holding weight", func(t *testing.T) {
+		sem := NewWeightedSemaphore(4)
+		assert.True(t, sem.TryAcquire(4))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(t, sem.Acquire(ctx, 2), context.DeadlineExceeded)
+
+		sem.Release(4)
+		assert.Equal(t, int64(0), sem.Used())
+		assert.True(t, sem.TryAcquire(4))
+	})
+
+	t.Run("should panic when releasing more than held", func(t *testing.T) {
+		sem := NewWeightedSemaphore(4)
+		assert.Panics(t, func() { sem.Release(1) })
+	})
+}
+
+func TestGoWeighted(t *testing.T) {
+	t.Run("should release the weight when the task finishes", func(t *testing.T) {
+		sem := NewWeightedSemaphore(3)
+		done := make(chan struct{})
+		assert.NoError(t, GoWeighted(sem, 3, func() { <-done }))
+		assert.Equal(t, int64(3), sem.Used())
+
+		close(done)
+		assert.Eventually(t, func() bool { return sem.Used() == 0 }, time.Second, time.Millisecond)
+	})
+
+	t.Run("should release the weight and log when the task panics", func(t *testing.T) {
+		mock := &mockLogger{}
+		SetLogger(mock)
+		defer SetLogger(&defaultLoggerImpl{})
+
+		sem := NewWeightedSemaphore(3)
+		assert.NoError(t, GoWeighted(sem, 2, func() { panic("heavy boom") }))
+		assert.Eventually(t, func() bool {
+			return sem.Used() == 0 && mock.getCallCount() == 1
+		}, time.Second, time.Millisecond)
+	})
+
+	t.Run("should reject tasks heavier than the capacity", func(t *testing.T) {
+		sem := NewWeightedSemaphore(3)
+		assert.ErrorIs(t, GoWeighted(sem, 4, func() { t.Error("should not run") }), ErrWeightExceedsCapacity)
+	})
+}
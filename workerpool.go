@@ -0,0 +1,112 @@
+package tsafe
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrWorkerPoolClosed is returned by WorkerPool.Submit after Close
+var ErrWorkerPoolClosed = errors.New("tsafe: worker pool is closed")
+
+// WorkerPool runs submitted tasks on a fixed set of long-lived worker goroutines
+// Unlike Pool, which starts a goroutine per task, it avoids goroutine churn at very high
+// task rates. Each task runs with panic recovery, so a panicking task is logged with the
+// configured logger and its worker goes on with the next task
+type WorkerPool struct {
+	tasks   chan func()
+	workers []workerCounters
+	wg      sync.WaitGroup
+	mu      sync.RWMutex // guards closed against concurrent sends
+	closed  bool
+}
+
+// NewWorkerPool starts a pool of workers goroutines
+// A non-positive workers is treated as 1. Call Close to stop the workers once the pool is unused
+func NewWorkerPool(workers int) *WorkerPool {
+	if workers <= 0 {
+		workers = 1
+	}
+	p := &WorkerPool{
+		tasks:   make(chan func(), workers),
+		workers: make([]workerCounters, workers),
+	}
+	p.wg.Add(workers)
+	for i := range p.workers {
+		counters := &p.workers[i]
+		spawn(func() { p.worker(counters) })
+	}
+	return p
+}
+
+// WorkerStat holds the counters of one worker of a WorkerPool
+type WorkerStat struct {
+	// ID identifies the worker, from 0 to the number of workers minus one
+	ID int
+	// Tasks is the number of tasks the worker finished, including panicking ones
+	Tasks uint64
+	// Panics is the number of tasks that panicked on the worker
+	Panics uint64
+}
+
+// workerCounters holds a worker's counters, accessed atomically
+type workerCounters struct {
+	tasks  uint64
+	panics uint64
+}
+
+// WorkerStats returns the counters of every worker, ordered by ID
+// A worker with disproportionately many panics may point at tasks poisoning shared state
+// This method is thread-safe
+func (p *WorkerPool) WorkerStats() []WorkerStat {
+	stats := make([]WorkerStat, len(p.workers))
+	for i := range p.workers {
+		stats[i] = WorkerStat{
+			ID:     i,
+			Tasks:  atomic.LoadUint64(&p.workers[i].tasks),
+			Panics: atomic.LoadUint64(&p.workers[i].panics),
+		}
+	}
+	return stats
+}
+
+// Submit hands fn to the next free worker, blocking while every worker is busy and the
+// queue, holding as many tasks as there are workers, is full
+// It returns ErrWorkerPoolClosed, without running fn, once Close was called
+func (p *WorkerPool) Submit(fn func()) error {
+	if fn == nil {
+		return nil
+	}
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return ErrWorkerPoolClosed
+	}
+	p.tasks <- fn
+	return nil
+}
+
+// Close stops accepting tasks, lets the workers finish the queued ones and waits for them to exit
+// Further calls are no-ops
+func (p *WorkerPool) Close() {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.tasks)
+	}
+	p.mu.Unlock()
+	p.wg.Wait()
+}
+
+// worker runs tasks until the pool is closed
+// Each task is recovered on its own, so a panicking task never ends the worker
+func (p *WorkerPool) worker(counters *workerCounters) {
+	defer p.wg.Done()
+	for fn := range p.tasks {
+		if pe := runRecovered(fn); pe != nil {
+			atomic.AddUint64(&counters.panics, 1)
+			reportPanic(pe.Value, pe.Stack, nil)
+		}
+		atomic.AddUint64(&counters.tasks, 1)
+	}
+}
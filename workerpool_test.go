@@ -0,0 +1,143 @@
+package tsafe
+
+import (
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkerPool(t *testing.T) {
+	t.Run("should run all submitted tasks", func(t *testing.T) {
+		pool := NewWorkerPool(4)
+		var count int32
+		for i := 0; i < 100; i++ {
+			assert.NoError(t, pool.Submit(func() { atomic.AddInt32(&count, 1) }))
+		}
+		pool.Close()
+		assert.Equal(t, int32(100), atomic.LoadInt32(&count))
+	})
+
+	t.Run("should run at most workers tasks at once", func(t *testing.T) {
+		pool := NewWorkerPool(2)
+		defer pool.Close()
+
+		var running, peak int32
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			_ = pool.Submit(func() {
+				defer wg.Done()
+				n := atomic.AddInt32(&running, 1)
+				for {
+					p := atomic.LoadInt32(&peak)
+					if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+						break
+					}
+				}
+				time.Sleep(time.Millisecond)
+				atomic.AddInt32(&running, -1)
+			})
+		}
+		wg.Wait()
+		assert.LessOrEqual(t, atomic.LoadInt32(&peak), int32(2))
+	})
+
+	t.Run("should recover panicking tasks and keep working", func(t *testing.T) {
+		mock := &mockLogger{}
+		SetLogger(mock)
+		defer SetLogger(&defaultLoggerImpl{})
+
+		pool := NewWorkerPool(1)
+		var count int32
+		_ = pool.Submit(func() { panic("poison task") })
+		_ = pool.Submit(func() { atomic.AddInt32(&count, 1) })
+		pool.Close()
+
+		assert.Equal(t, int32(1), atomic.LoadInt32(&count))
+		assert.Equal(t, 1, mock.getCallCount())
+	})
+
+	t.Run("should keep a worker running tasks after a panic", func(t *testing.T) {
+		SetLogger(&mockLogger{})
+		defer SetLogger(&defaultLoggerImpl{})
+
+		pool := NewWorkerPool(1)
+		var ids []uint64
+		var mu sync.Mutex
+		record := func() {
+			mu.Lock()
+			defer mu.Unlock()
+			ids = append(ids, goroutineID(debug.Stack()))
+		}
+		_ = pool.Submit(record)
+		_ = pool.Submit(func() { panic("poison task") })
+		_ = pool.Submit(record)
+		pool.Close()
+
+		if assert.Len(t, ids, 2) {
+			assert.Equal(t, ids[0], ids[1], "normal tasks should run on the same worker")
+		}
+		assert.Equal(t, []WorkerStat{{ID: 0, Tasks: 3, Panics: 1}}, pool.WorkerStats())
+	})
+
+	t.Run("should count tasks and panics per worker", func(t *testing.T) {
+		SetLogger(&mockLogger{})
+		defer SetLogger(&defaultLoggerImpl{})
+
+		pool := NewWorkerPool(3)
+		for i := 0; i < 30; i++ {
+			i := i
+			_ = pool.Submit(func() {
+				if i%10 == 0 {
+					panic("poison task")
+				}
+			})
+		}
+		pool.Close()
+
+		stats := pool.WorkerStats()
+		assert.Len(t, stats, 3)
+		var tasks, panics uint64
+		for i, s := range stats {
+			assert.Equal(t, i, s.ID)
+			tasks += s.Tasks
+			panics += s.Panics
+		}
+		assert.Equal(t, uint64(30), tasks)
+		assert.Equal(t, uint64(3), panics)
+	})
+
+	t.Run("should reject tasks after Close", func(t *testing.T) {
+		pool := NewWorkerPool(1)
+		pool.Close()
+		pool.Close()
+		assert.ErrorIs(t, pool.Submit(func() {}), ErrWorkerPoolClosed)
+	})
+}
+
+func BenchmarkWorkerPool(b *testing.B) {
+	pool := NewWorkerPool(8)
+	defer pool.Close()
+	var wg sync.WaitGroup
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		wg.Add(1)
+		_ = pool.Submit(wg.Done)
+	}
+	wg.Wait()
+}
+
+func BenchmarkPoolSpawnPerTask(b *testing.B) {
+	pool := NewPool(8)
+	var wg sync.WaitGroup
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		wg.Add(1)
+		_ = pool.Submit(wg.Done)
+	}
+	wg.Wait()
+}
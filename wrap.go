@@ -0,0 +1,31 @@
+package tsafe
+
+// Wrap returns a function that runs fn with panic recovery in the caller's goroutine
+// A panic is logged with the configured logger and the returned function returns normally,
+// which makes any callback panic-safe without spawning, e.g. when it is invoked by a third-party library
+// A nil fn yields a function that does nothing
+func Wrap(fn func()) func() {
+	if fn == nil {
+		return func() {}
+	}
+	return func() {
+		if pe := runRecovered(fn); pe != nil {
+			reportPanic(pe.Value, pe.Stack, nil)
+		}
+	}
+}
+
+// WrapErr returns a function that runs fn with panic recovery in the caller's goroutine
+// It returns fn's error, or the panic as a *PanicError; the panic is not logged since the caller receives it
+// A nil fn yields a function that returns nil
+func WrapErr(fn func() error) func() error {
+	if fn == nil {
+		return func() error { return nil }
+	}
+	return func() (err error) {
+		if pe := runRecovered(func() { err = fn() }); pe != nil {
+			return pe
+		}
+		return err
+	}
+}
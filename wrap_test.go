@@ -0,0 +1,50 @@
+package tsafe
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrap(t *testing.T) {
+	t.Run("should recover and log panics synchronously", func(t *testing.T) {
+		mock := &mockLogger{}
+		SetLogger(mock)
+		defer SetLogger(&defaultLoggerImpl{})
+
+		safe := Wrap(func() { panic("wrapped panic") })
+		assert.NotPanics(t, safe)
+		assert.Equal(t, 1, mock.getCallCount())
+		assert.Equal(t, "wrapped panic", mock.getLastError())
+	})
+
+	t.Run("should run the function in the caller's goroutine", func(t *testing.T) {
+		ran := false
+		Wrap(func() { ran = true })()
+		assert.True(t, ran)
+		assert.NotPanics(t, Wrap(nil))
+	})
+}
+
+func TestWrapErr(t *testing.T) {
+	t.Run("should return the function's error", func(t *testing.T) {
+		want := errors.New("wrapped error")
+		assert.Equal(t, want, WrapErr(func() error { return want })())
+		assert.NoError(t, WrapErr(nil)())
+	})
+
+	t.Run("should return panics as PanicError without logging", func(t *testing.T) {
+		mock := &mockLogger{}
+		SetLogger(mock)
+		defer SetLogger(&defaultLoggerImpl{})
+
+		err := WrapErr(func() error { panic("wrapped panic") })()
+		assert.ErrorIs(t, err, ErrPanic)
+		var pe *PanicError
+		if assert.True(t, errors.As(err, &pe)) {
+			assert.Equal(t, "wrapped panic", pe.Value)
+		}
+		assert.Equal(t, 0, mock.getCallCount())
+	})
+}